@@ -0,0 +1,42 @@
+package main
+
+import "fmt"
+
+// logBreakdown writes, at debug level, how team's total was reached: the
+// cut handling applied, each player's part in the total, and the running
+// total as counted players are added. team is as built by getTeamScores,
+// with the Total row last; buildTeams logs which team it is beforehand.
+func logBreakdown(team []Player, cutVal int, opts ScoreOptions) {
+	if minLogLevel > levelDebug || len(team) == 0 {
+		return
+	}
+
+	mode := opts.CutMode
+	if mode == "" {
+		mode = cutModePenalty
+	}
+	switch mode {
+	case cutModePenalty:
+		debugf("missed rounds score %s (cut penalty %d, mode %s)", formatToPar(cutVal), opts.CutPenalty, mode)
+	default:
+		debugf("cut mode %s", mode)
+	}
+
+	running := 0
+	for _, p := range team[:len(team)-1] {
+		detail := formatToPar(p.Total)
+		if p.Handicap != 0 {
+			detail = fmt.Sprintf("%s (gross %s, hcp %d)", detail, formatToPar(p.Gross), p.Handicap)
+		}
+		switch {
+		case p.Scratched:
+			debugf("  scratched %-24s %s", p.FullName, detail)
+		case p.Excluded:
+			debugf("  excluded  %-24s %s", p.FullName, detail)
+		default:
+			running += p.Total
+			debugf("  selected  %-24s %s, running %s", p.FullName, detail, formatToPar(running))
+		}
+	}
+	debugf("total %s", formatToPar(team[len(team)-1].Total))
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestLogBreakdown(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+	prev := minLogLevel
+	minLogLevel = levelDebug
+	defer func() { minLogLevel = prev }()
+
+	leaderboard := mustLoadLeaderboard(t, "testdata/leaderboard.json")
+	opts := ScoreOptions{Count: 2, CutPenalty: 3}
+	team := getTeamScores(leaderboard, Team{Players: []string{"Adam One", "Ben Two", "Eric Five"}}, opts)
+	logBreakdown(team, missedRoundScore(leaderboard, opts), opts)
+
+	out := buf.String()
+	for _, want := range []string{
+		"missed rounds score +5 (cut penalty 3, mode penalty)",
+		"selected  Adam One                 -6, running -6",
+		"selected  Ben Two                  -4, running -10",
+		"excluded  Eric Five",
+		"total -10",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("breakdown missing %q in:\n%s", want, out)
+		}
+	}
+}
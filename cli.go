@@ -0,0 +1,804 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// run dispatches to a subcommand named by args[0]:
+//
+//	fetch     refresh the leaderboard JSON and exit
+//	render    build the scoreboard from the saved leaderboard
+//	serve     serve a live scoreboard over HTTP
+//	report    summarize every pick's contribution across the saved history
+//	schedule  list the year's tournaments and their IDs for -tourn
+//	series    award season points for every tournament in the saved history
+//	teetimes  write an iCalendar file of the picks' tee times
+//
+// With no subcommand, run keeps the original all-in-one behavior: every flag
+// is accepted, and the leaderboard is fetched first when -refresh or -force
+// is set or it hasn't been downloaded yet.
+//
+// A fetch is skipped while the cached leaderboard is younger than -max-age,
+// and otherwise asks the API for a 304 if it hasn't changed. -force bypasses
+// both and always downloads the full leaderboard; -rate still applies.
+func run(args []string) error {
+	if len(args) > 0 {
+		switch args[0] {
+		case "fetch":
+			return runFetch(args[1:])
+		case "render":
+			return runRender(args[1:])
+		case "serve":
+			return runServe(args[1:])
+		case "report":
+			return runReport(args[1:])
+		case "schedule":
+			return runSchedule(args[1:])
+		case "series":
+			return runSeries(args[1:])
+		case "teetimes":
+			return runTeeTimes(args[1:])
+		}
+	}
+	return runDefault(args)
+}
+
+// commonFlags are accepted by every subcommand: where the pool config lives,
+// which tournament to fetch, and how to talk to the API.
+type commonFlags struct {
+	configPath *string
+	teamsDir   *string
+	tourn      *string
+	org        *string
+	year       *string
+	timeout    *time.Duration
+	retries    *int
+	maxAge     *time.Duration
+	force      *bool
+	apiKeyFile *string
+	rate       *int
+	sourceURL  *string
+	headers    headerFlags
+	tz         *string
+	verbose    *bool
+	quiet      *bool
+}
+
+func addCommonFlags(fs *flag.FlagSet) *commonFlags {
+	c := &commonFlags{
+		configPath: fs.String("config", "config.json", "Path to pool config file"),
+		teamsDir:   fs.String("teams-dir", "", "Load every team file in this directory instead of the config's teamsDir and members"),
+		tourn:      fs.String("tourn", tournID, "Tournament ID to fetch"),
+		org:        fs.String("org", orgID, "Organization ID to fetch (1 = PGA Tour)"),
+		year:       fs.String("year", tournYear, "Tournament year to fetch"),
+		timeout:    fs.Duration("timeout", 15*time.Second, "HTTP timeout for each API request"),
+		retries:    fs.Int("retries", 3, "Maximum API attempts on network errors and 5xx responses"),
+		maxAge:     fs.Duration("max-age", 0, "Reuse a cached leaderboard.json younger than this instead of fetching (0 always fetches)"),
+		force:      fs.Bool("force", false, "Always download the full leaderboard, ignoring -max-age and the cached ETag (implies -refresh)"),
+		apiKeyFile: fs.String("api-key-file", "", "Read the RapidAPI key from this file instead of RAPID_GOLF_API_KEY"),
+		rate:       fs.Int("rate", 0, "Maximum API requests per minute, shared across runs; over the limit the cached leaderboard is kept (0 is unlimited)"),
+		sourceURL:  fs.String("source-url", "", "Fetch leaderboard JSON from this URL instead of RapidAPI"),
+		tz:         fs.String("tz", "", "Time zone for the scoreboard's timestamps, e.g. America/New_York (default local)"),
+		verbose:    fs.Bool("v", false, "Verbose logging, including debug messages"),
+		quiet:      fs.Bool("quiet", false, "Only log warnings and errors"),
+	}
+	fs.Var(&c.headers, "header", "Extra `key=value` header for leaderboard requests, e.g. auth for -source-url (repeatable)")
+	return c
+}
+
+// headerFlags collects repeated -header key=value flags.
+type headerFlags []string
+
+func (h *headerFlags) String() string { return strings.Join(*h, ", ") }
+
+func (h *headerFlags) Set(value string) error {
+	if key, _, ok := strings.Cut(value, "="); !ok || strings.TrimSpace(key) == "" {
+		return fmt.Errorf("want key=value, got %q", value)
+	}
+	*h = append(*h, value)
+	return nil
+}
+
+// header returns the collected headers for a request.
+func (h headerFlags) header() http.Header {
+	header := http.Header{}
+	for _, kv := range h {
+		key, value, _ := strings.Cut(kv, "=")
+		header.Add(strings.TrimSpace(key), strings.TrimSpace(value))
+	}
+	return header
+}
+
+// setup applies the logging flags and loads the pool config, returning it
+// along with the options for fetching its leaderboard.
+func (c *commonFlags) setup() (Config, FetchOptions, error) {
+	switch {
+	case *c.verbose:
+		minLogLevel = levelDebug
+	case *c.quiet:
+		minLogLevel = levelWarn
+	}
+
+	if *c.tz != "" {
+		loc, err := time.LoadLocation(*c.tz)
+		if err != nil {
+			return Config{}, FetchOptions{}, fmt.Errorf("invalid -tz: %v", err)
+		}
+		displayLocation = loc
+	}
+
+	cfg, err := loadConfig(*c.configPath)
+	if err != nil {
+		return Config{}, FetchOptions{}, fmt.Errorf("failed to load config: %v", err)
+	}
+	for name, split := range cfg.NameOverrides {
+		nameOverrides[name] = split
+	}
+	cfg.RosterLock = rosterLockPath(*c.tourn)
+	if *c.teamsDir != "" {
+		cfg.TeamsDir = *c.teamsDir
+		cfg.Members = nil
+	}
+
+	opts := FetchOptions{
+		OrgID:   *c.org,
+		TournID: *c.tourn,
+		Year:    *c.year,
+		Timeout: *c.timeout,
+		Retries: *c.retries,
+		MaxAge:  *c.maxAge,
+		OutPath: cfg.Leaderboard,
+
+		APIKeyFile:    *c.apiKeyFile,
+		RatePerMinute: *c.rate,
+		SourceURL:     *c.sourceURL,
+		Headers:       c.headers.header(),
+		Force:         *c.force,
+	}
+	return cfg, opts, nil
+}
+
+// scoreFlags control how teams are scored, for every subcommand that scores.
+type scoreFlags struct {
+	count       *int
+	project     *bool
+	rounds      *string
+	cutPenalty  *int
+	cutMode     *string
+	eventRounds *int
+	order       *string
+	round       *int
+	sinceCut    *bool
+	trustTotal  *bool
+	strict      *bool
+	leaderboard *string
+	overrides   *string
+	scoring     *string
+}
+
+func addScoreFlags(fs *flag.FlagSet) *scoreFlags {
+	return &scoreFlags{
+		count:       fs.Int("count", 4, "Number of lowest player totals that count toward the team total"),
+		project:     fs.Bool("project", false, "Show a projected finish that fills unplayed rounds with the field average"),
+		rounds:      fs.String("rounds", "", "Comma-separated rounds that count toward totals, e.g. 3,4 (default all)"),
+		cutPenalty:  fs.Int("cut-penalty", 3, "Strokes over the cut line scored for each round a cut, WD, or DQ player misses"),
+		cutMode:     fs.String("cut-mode", cutModePenalty, "How missed rounds score: \"penalty\" (cut line plus -cut-penalty), \"zero\" (even), or \"drop\" (player doesn't count)"),
+		eventRounds: fs.Int("event-rounds", 4, "Number of rounds in the event, for shortened or 3-round events (1-4)"),
+		order:       fs.String("order", "total", "List each team's players by \"total\" or by leaderboard \"position\""),
+		round:       fs.Int("round", 0, "Rank teams by their counted players' scores in this round only, e.g. 3 for Saturday (default overall)"),
+		sinceCut:    fs.Bool("since-cut", false, "Rank teams by their counted players' weekend (R3+R4) scores, leaving out anyone who missed the cut"),
+		trustTotal:  fs.Bool("trust-total", false, "Score players by the leaderboard's total to par instead of summing their rounds"),
+		strict:      fs.Bool("strict", false, "Fail instead of warning when teams break draft rules"),
+		leaderboard: fs.String("leaderboard", "", "Score this leaderboard JSON file instead of the fetched leaderboard.json"),
+		overrides:   fs.String("overrides", "", "Commissioner score corrections to apply (default overrides/<tourn>.json if it exists)"),
+		scoring:     fs.String("scoring", scoringToPar, "How team totals are expressed: topar, each counted player's rounds relative to par with cut penalties in the same terms"),
+	}
+}
+
+// apply validates the scoring flags, applies -strict and -leaderboard to
+// cfg, and returns the resulting ScoreOptions with tourn's score overrides.
+func (s *scoreFlags) apply(cfg *Config, tourn string) (ScoreOptions, error) {
+	rounds, err := parseRounds(*s.rounds)
+	if err != nil {
+		return ScoreOptions{}, err
+	}
+	if *s.count < 1 {
+		return ScoreOptions{}, fmt.Errorf("invalid -count %d: must be at least 1", *s.count)
+	}
+	if *s.eventRounds < 1 || *s.eventRounds > 4 {
+		return ScoreOptions{}, fmt.Errorf("invalid -event-rounds %d: must be 1-4", *s.eventRounds)
+	}
+	if *s.round < 0 || *s.round > *s.eventRounds {
+		return ScoreOptions{}, fmt.Errorf("invalid -round %d: must be 1-%d", *s.round, *s.eventRounds)
+	}
+	score := ScoreOptions{Count: *s.count, CutPenalty: *s.cutPenalty, Project: *s.project, Rounds: rounds, EventRounds: *s.eventRounds, RankRound: *s.round, SinceCut: *s.sinceCut, TrustTotal: *s.trustTotal}
+	if n := len(cfg.RoundWeights); n > 0 {
+		if n != *s.eventRounds {
+			return ScoreOptions{}, fmt.Errorf("config roundWeights has %d weights but the event has %d rounds", n, *s.eventRounds)
+		}
+		if slices.ContainsFunc(cfg.RoundWeights, func(w int) bool { return w < 0 }) {
+			return ScoreOptions{}, fmt.Errorf("config roundWeights can't be negative: %v", cfg.RoundWeights)
+		}
+		if *s.trustTotal {
+			return ScoreOptions{}, fmt.Errorf("-trust-total can't be used with config roundWeights; the leaderboard's total isn't weighted")
+		}
+		score.RoundWeights = cfg.RoundWeights
+	}
+	if score.SinceCut && *s.eventRounds < 3 {
+		return ScoreOptions{}, fmt.Errorf("-since-cut needs an event of at least 3 rounds")
+	}
+	switch *s.cutMode {
+	case cutModePenalty, cutModeZero, cutModeDrop:
+		score.CutMode = *s.cutMode
+	default:
+		return ScoreOptions{}, fmt.Errorf("invalid -cut-mode %q: want penalty, zero, or drop", *s.cutMode)
+	}
+	if *s.scoring != scoringToPar {
+		return ScoreOptions{}, fmt.Errorf("invalid -scoring %q: want topar", *s.scoring)
+	}
+	switch *s.order {
+	case "total":
+	case "position":
+		score.ByPosition = true
+	default:
+		return ScoreOptions{}, fmt.Errorf("invalid -order %q: want total or position", *s.order)
+	}
+
+	overridesFile := *s.overrides
+	if overridesFile == "" {
+		overridesFile = overridesPath(tourn)
+	}
+	if score.Overrides, err = loadOverrides(overridesFile); err != nil {
+		return ScoreOptions{}, fmt.Errorf("failed to load score overrides: %v", err)
+	}
+
+	if *s.strict {
+		cfg.Strict = true
+	}
+	// -leaderboard only changes what gets scored; fetches still save to
+	// the configured path so a saved fixture is never overwritten.
+	if *s.leaderboard != "" {
+		cfg.Leaderboard = *s.leaderboard
+	}
+	return score, nil
+}
+
+const checkHTMLUsage = "Sanity-check each rendered page and keep the previous one if it looks broken"
+
+// outputFlags choose what a render writes besides the scoreboard page.
+type outputFlags struct {
+	out       *string
+	writeJSON *bool
+	writeCSV  *bool
+	snapshot  *bool
+	notify    *bool
+	slack     *bool
+	printOnly *bool
+	whatIf    *string
+	pairings  *string
+	team      *string
+	layout    *string
+	hideExtra *bool
+}
+
+func addOutputFlags(fs *flag.FlagSet) *outputFlags {
+	fs.StringVar(&templatePath, "template", "", "Render with this scoreboard template instead of the built-in one")
+	fs.BoolVar(&noTimestamp, "no-timestamp", false, "Leave the last-updated time off the page, so unchanged data renders identical HTML")
+	fs.BoolVar(&checkHTML, "check-html", false, checkHTMLUsage)
+	return &outputFlags{
+		out:       fs.String("out", "docs/index.html", "Path to write the rendered scoreboard HTML to"),
+		writeJSON: fs.Bool("json", false, "Also write standings to standings.json"),
+		writeCSV:  fs.Bool("csv", false, "Also write standings to standings.csv"),
+		snapshot:  fs.Bool("snapshot", false, "Append the standings to history/<tourn>.jsonl"),
+		notify:    fs.Bool("notify", false, "Post to DISCORD_WEBHOOK_URL when the team order changes (implies -snapshot)"),
+		slack:     fs.Bool("notify-slack", false, "Post to SLACK_WEBHOOK_URL when the team order changes (implies -snapshot)"),
+		printOnly: fs.Bool("print", false, "Print the standings to the terminal instead of writing any files"),
+		whatIf:    fs.String("what-if", "", "Print standings simulated with the R4 scores in this JSON file (player name to score to par)"),
+		pairings:  fs.String("pairings", "", "Add head-to-head results for the team pairings in this JSON file"),
+		team:      fs.String("team", "", "Only show the team with this name; standings are still scored against the whole pool"),
+		layout:    fs.String("layout", layoutTable, "Scoreboard layout: \"table\" or the mobile-first \"compact\" cards"),
+		hideExtra: fs.Bool("hide-excluded", false, "Only list each team's counting players and total on the page"),
+	}
+}
+
+// snapshotPath returns where to record standings for tourn, or "" when
+// none of -snapshot, -notify, and -notify-slack is set.
+func (o *outputFlags) snapshotPath(tourn string) string {
+	if *o.snapshot || o.notifiers().any() {
+		return historyPath(tourn)
+	}
+	return ""
+}
+
+// notifiers returns the chats the notify flags post to.
+func (o *outputFlags) notifiers() Notifiers {
+	return Notifiers{Discord: *o.notify, Slack: *o.slack}
+}
+
+// flagConflicts are pairs of flags that can't be used in the same run,
+// usually because one would silently ignore the other.
+var flagConflicts = [][2]string{
+	{"v", "quiet"},
+	{"force", "max-age"},
+	{"source-url", "api-key-file"},
+
+	{"print", "out"},
+	{"print", "json"},
+	{"print", "csv"},
+	{"print", "snapshot"},
+	{"print", "notify"},
+	{"print", "notify-slack"},
+	{"print", "pairings"},
+
+	{"what-if", "serve"},
+	{"what-if", "interval"},
+	{"what-if", "tournaments"},
+	{"what-if", "out"},
+	{"what-if", "json"},
+	{"what-if", "csv"},
+	{"what-if", "snapshot"},
+	{"what-if", "notify"},
+	{"what-if", "notify-slack"},
+	{"what-if", "pairings"},
+
+	{"serve", "interval"},
+	{"serve", "print"},
+	{"serve", "out"},
+	{"serve", "json"},
+	{"serve", "csv"},
+	{"serve", "snapshot"},
+	{"serve", "notify"},
+	{"serve", "notify-slack"},
+	{"serve", "pairings"},
+	{"serve", "team"},
+	{"serve", "layout"},
+
+	{"interval", "tournaments"},
+	{"interval", "print"},
+	{"interval", "json"},
+	{"interval", "csv"},
+	{"interval", "pairings"},
+	{"interval", "team"},
+	{"interval", "layout"},
+	{"tournaments", "layout"},
+	// Each -tournaments entry only renders its own HTML page.
+	{"tournaments", "print"},
+	{"tournaments", "out"},
+	{"tournaments", "json"},
+	{"tournaments", "csv"},
+	{"tournaments", "team"},
+	{"tournaments", "snapshot"},
+	{"tournaments", "notify"},
+	{"tournaments", "notify-slack"},
+	{"tournaments", "pairings"},
+	{"print", "layout"},
+	{"serve", "hide-excluded"},
+	{"interval", "hide-excluded"},
+	{"tournaments", "hide-excluded"},
+	{"print", "hide-excluded"},
+
+	// Snapshots feed rank movement, which is always overall standings.
+	{"round", "snapshot"},
+	{"round", "notify"},
+	{"round", "notify-slack"},
+	{"round", "since-cut"},
+	{"since-cut", "snapshot"},
+	{"since-cut", "notify"},
+	{"since-cut", "notify-slack"},
+
+	// The leaderboard's total covers every round and the real R4.
+	{"trust-total", "rounds"},
+	{"trust-total", "what-if"},
+}
+
+// checkFlagConflicts returns an error listing every pair of flagConflicts
+// set on fs.
+func checkFlagConflicts(fs *flag.FlagSet) error {
+	set := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+
+	var conflicts []string
+	for _, pair := range flagConflicts {
+		if set[pair[0]] && set[pair[1]] {
+			conflicts = append(conflicts, fmt.Sprintf("-%s with -%s", pair[0], pair[1]))
+		}
+	}
+	if len(conflicts) > 0 {
+		return fmt.Errorf("incompatible flags: %s", strings.Join(conflicts, ", "))
+	}
+	return nil
+}
+
+// runFetch implements the fetch subcommand.
+func runFetch(args []string) error {
+	fs := flag.NewFlagSet("fetch", flag.ExitOnError)
+	common := addCommonFlags(fs)
+	fs.Parse(args)
+	if err := checkFlagConflicts(fs); err != nil {
+		return err
+	}
+
+	_, opts, err := common.setup()
+	if err != nil {
+		return err
+	}
+	if err := fetchLeaderboard(opts); err != nil {
+		return fmt.Errorf("failed to refresh leaderboard: %v", err)
+	}
+	infof("✅ Fetched latest leaderboard")
+	return nil
+}
+
+// runRender implements the render subcommand.
+func runRender(args []string) error {
+	fs := flag.NewFlagSet("render", flag.ExitOnError)
+	common := addCommonFlags(fs)
+	scoring := addScoreFlags(fs)
+	output := addOutputFlags(fs)
+	fs.Parse(args)
+	if err := checkFlagConflicts(fs); err != nil {
+		return err
+	}
+
+	cfg, _, err := common.setup()
+	if err != nil {
+		return err
+	}
+	score, err := scoring.apply(&cfg, *common.tourn)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(cfg.Leaderboard); os.IsNotExist(err) {
+		return fmt.Errorf("leaderboard file %s not found; run fetch first", cfg.Leaderboard)
+	}
+	return renderStandings(cfg, score, *common.tourn, output)
+}
+
+// runServe implements the serve subcommand.
+func runServe(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	common := addCommonFlags(fs)
+	scoring := addScoreFlags(fs)
+	addr := fs.String("addr", ":8080", "Listen address")
+	tournamentsPath := fs.String("tournaments", "", "Path to a JSON list of tournaments to offer at /api/standings?tourn=")
+	fs.BoolVar(&checkHTML, "check-html", false, checkHTMLUsage)
+	fs.Parse(args)
+	if err := checkFlagConflicts(fs); err != nil {
+		return err
+	}
+
+	cfg, opts, err := common.setup()
+	if err != nil {
+		return err
+	}
+	score, err := scoring.apply(&cfg, *common.tourn)
+	if err != nil {
+		return err
+	}
+
+	var tcs []TournamentConfig
+	if *tournamentsPath != "" {
+		if tcs, err = loadPoolTournaments(*tournamentsPath, cfg, opts, score, false); err != nil {
+			return err
+		}
+	}
+	return serveScoreboard(*addr, cfg, score, historyPath(*common.tourn), tcs)
+}
+
+// runReport implements the report subcommand.
+func runReport(args []string) error {
+	fs := flag.NewFlagSet("report", flag.ExitOnError)
+	dir := fs.String("history", "history", "Directory of tournament snapshots to aggregate")
+	asJSON := fs.Bool("json", false, "Write the report as JSON instead of a table")
+	fs.Parse(args)
+
+	report, err := picksReport(*dir)
+	if err != nil {
+		return fmt.Errorf("picks report: %v", err)
+	}
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+	return writePicksReportText(report, os.Stdout)
+}
+
+// runSchedule implements the schedule subcommand.
+func runSchedule(args []string) error {
+	fs := flag.NewFlagSet("schedule", flag.ExitOnError)
+	common := addCommonFlags(fs)
+	refresh := fs.Bool("refresh", false, "Download the full schedule again, ignoring -max-age and the cached ETag (same as -force)")
+	fs.Parse(args)
+	if err := checkFlagConflicts(fs); err != nil {
+		return err
+	}
+
+	_, opts, err := common.setup()
+	if err != nil {
+		return err
+	}
+	opts.Force = opts.Force || *refresh
+	events, err := fetchSchedule(opts)
+	if err != nil {
+		return err
+	}
+	return writeScheduleText(events, os.Stdout)
+}
+
+// runSeries implements the series subcommand.
+func runSeries(args []string) error {
+	fs := flag.NewFlagSet("series", flag.ExitOnError)
+	dir := fs.String("history", "history", "Directory of tournament snapshots to score")
+	points := fs.String("points", defaultSeriesPoints, "Comma-separated series points for each finishing place, starting with the winner")
+	out := fs.String("out", "docs/series.html", "Path to write the season standings page to")
+	printOnly := fs.Bool("print", false, "Print the season standings to the terminal instead of writing the page")
+	fs.BoolVar(&noTimestamp, "no-timestamp", false, "Leave the last-updated time off the page, so unchanged data renders identical HTML")
+	fs.Parse(args)
+	if err := checkFlagConflicts(fs); err != nil {
+		return err
+	}
+
+	table, err := parsePointsTable(*points)
+	if err != nil {
+		return err
+	}
+	standings, tourns, err := seriesStandings(*dir, table)
+	if err != nil {
+		return fmt.Errorf("series standings: %v", err)
+	}
+	if *printOnly {
+		return writeSeriesText(standings, tourns, os.Stdout)
+	}
+	page := newSeriesPage(standings, tourns, table)
+	if err := writePage(*out, func(w io.Writer) error { return writeSeriesPage(w, page) }); err != nil {
+		return fmt.Errorf("render failed: %v", err)
+	}
+	infof("✅ Season standings for %d tournaments written to %s", len(tourns), *out)
+	return nil
+}
+
+// runTeeTimes implements the teetimes subcommand.
+func runTeeTimes(args []string) error {
+	fs := flag.NewFlagSet("teetimes", flag.ExitOnError)
+	common := addCommonFlags(fs)
+	scoring := addScoreFlags(fs)
+	team := fs.String("team", "", "Only include this team's picks (default every team's)")
+	out := fs.String("out", "tee-times.ics", "Path to write the iCalendar file to")
+	fs.Parse(args)
+	if err := checkFlagConflicts(fs); err != nil {
+		return err
+	}
+
+	cfg, _, err := common.setup()
+	if err != nil {
+		return err
+	}
+	score, err := scoring.apply(&cfg, *common.tourn)
+	if err != nil {
+		return err
+	}
+	cfg.RosterLockReadOnly = true
+	teams, _, err := buildTeams(cfg, score)
+	if err != nil {
+		return err
+	}
+	if *team != "" {
+		t, err := findTeam(teams, *team)
+		if err != nil {
+			return err
+		}
+		teams = []Team{t}
+	}
+
+	var players []Player
+	for _, t := range teams {
+		players = append(players, t.PlayerScores...)
+	}
+	if !slices.ContainsFunc(players, func(p Player) bool { return p.TeeTime != nil }) {
+		warnf("⚠️ None of the picks has a tee time in %s; the calendar will be empty", cfg.Leaderboard)
+	}
+	file, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	if err := writeTeeTimesICS(players, file); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	infof("✅ Tee times written to %s", *out)
+	return nil
+}
+
+// runDefault implements the original flag-only interface, used when no
+// subcommand is given.
+func runDefault(args []string) error {
+	fs := flag.NewFlagSet("pga-tracker", flag.ExitOnError)
+	common := addCommonFlags(fs)
+	scoring := addScoreFlags(fs)
+	output := addOutputFlags(fs)
+	refresh := fs.Bool("refresh", false, "Fetch latest leaderboard from API")
+	serve := fs.Bool("serve", false, "Serve the scoreboard over HTTP instead of writing the -out file")
+	addr := fs.String("addr", ":8080", "Listen address for -serve")
+	interval := fs.Duration("interval", 0, "Fetch and re-render on this interval until interrupted (0 runs once)")
+	tournamentsPath := fs.String("tournaments", "", "Path to a JSON list of tournaments to process in parallel")
+	fs.Parse(args)
+	if err := checkFlagConflicts(fs); err != nil {
+		return err
+	}
+
+	cfg, opts, err := common.setup()
+	if err != nil {
+		return err
+	}
+	score, err := scoring.apply(&cfg, *common.tourn)
+	if err != nil {
+		return err
+	}
+
+	if opts.Force {
+		*refresh = true
+	}
+
+	var tcs []TournamentConfig
+	if *tournamentsPath != "" {
+		if tcs, err = loadPoolTournaments(*tournamentsPath, cfg, opts, score, *refresh); err != nil {
+			return err
+		}
+		// With -serve, the tournaments are only made available to
+		// /api/standings?tourn=.
+		if !*serve {
+			return processTournaments(tcs)
+		}
+	}
+
+	if *interval > 0 {
+		return watch(*interval, opts, cfg, score, *output.out, output.snapshotPath(*common.tourn), output.notifiers())
+	}
+
+	if *refresh {
+		if err := fetchLeaderboard(opts); err != nil {
+			return fmt.Errorf("failed to refresh leaderboard: %v", err)
+		}
+		infof("✅ Fetched latest leaderboard")
+	} else if _, err := os.Stat(cfg.Leaderboard); os.IsNotExist(err) {
+		if cfg.Leaderboard != opts.OutPath {
+			return fmt.Errorf("leaderboard file %s not found", cfg.Leaderboard)
+		}
+		infof("No %s yet; fetching it now", cfg.Leaderboard)
+		if err := fetchLeaderboard(opts); err != nil {
+			errorf("Couldn't fetch the leaderboard (%v). Set RAPID_GOLF_API_KEY and run with -refresh to download it first.", err)
+			return nil
+		}
+		infof("✅ Fetched latest leaderboard")
+	}
+
+	if *serve {
+		return serveScoreboard(*addr, cfg, score, historyPath(*common.tourn), tcs)
+	}
+	return renderStandings(cfg, score, *common.tourn, output)
+}
+
+// loadPoolTournaments loads a -tournaments file and fills in each entry from
+// the command-line pool config and options.
+func loadPoolTournaments(path string, cfg Config, opts FetchOptions, score ScoreOptions, refresh bool) ([]TournamentConfig, error) {
+	tcs, err := loadTournaments(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tournaments: %v", err)
+	}
+	for i := range tcs {
+		tcs[i].Pool = cfg
+		tcs[i].Fetch = opts
+		tcs[i].Score = score
+		// Overrides are per tournament, so each pool loads its own.
+		if tcs[i].Score.Overrides, err = loadOverrides(overridesPath(tcs[i].TournID)); err != nil {
+			return nil, fmt.Errorf("failed to load score overrides: %v", err)
+		}
+		tcs[i].Refresh = refresh
+	}
+	return tcs, nil
+}
+
+// renderStandings scores the pool from its saved leaderboard and writes the
+// scoreboard page and whatever else output asks for.
+func renderStandings(cfg Config, score ScoreOptions, tourn string, output *outputFlags) error {
+	switch *output.layout {
+	case layoutTable, layoutCompact:
+	default:
+		return fmt.Errorf("invalid -layout %q: want table or compact", *output.layout)
+	}
+	if *output.whatIf != "" {
+		var err error
+		score.WhatIf, err = loadWhatIf(*output.whatIf)
+		if err != nil {
+			return fmt.Errorf("failed to load what-if scores: %v", err)
+		}
+	}
+
+	// Only a published scoreboard locks the rosters.
+	cfg.RosterLockReadOnly = score.WhatIf != nil || *output.printOnly
+	teams, skipped, err := buildTeams(cfg, score)
+	if err != nil {
+		return err
+	}
+	loadRankDeltas(historyPath(tourn), teams)
+
+	// -team only narrows what's shown; history and exports keep every team.
+	shown := teams
+	if *output.team != "" {
+		team, err := findTeam(teams, *output.team)
+		if err != nil {
+			return err
+		}
+		shown = []Team{team}
+	}
+
+	// A simulation is never written anywhere it could be mistaken for the
+	// real standings.
+	if score.WhatIf != nil {
+		fmt.Printf("🔮 SIMULATION: R4 scores from %s, not real results\n\n", *output.whatIf)
+		return writeStandingsText(shown, os.Stdout)
+	}
+
+	if *output.printOnly {
+		return writeStandingsText(shown, os.Stdout)
+	}
+
+	meta := tournamentMeta(cfg.Leaderboard, tournName)
+	data := newPageData(shown, skipped, meta, score)
+	data.Layout = *output.layout
+	if *output.hideExtra {
+		data.Teams = countingPlayersOnly(data.Teams)
+	}
+	if *output.pairings != "" {
+		pairings, err := loadPairings(*output.pairings)
+		if err != nil {
+			return fmt.Errorf("failed to load pairings: %v", err)
+		}
+		if data.Matchups, err = matchups(pairings, teams); err != nil {
+			return err
+		}
+	}
+	if err := renderScoreboard(data, *output.out); err != nil {
+		return fmt.Errorf("render failed: %v", err)
+	}
+
+	if snapshotPath := output.snapshotPath(tourn); snapshotPath != "" {
+		notify := output.notifiers()
+		notify.Tournament = meta.Name
+		if err := recordStandings(snapshotPath, teams, notify); err != nil {
+			return fmt.Errorf("failed to save snapshot: %v", err)
+		}
+	}
+
+	if *output.writeJSON {
+		if err := writeStandingsJSON(teams, "standings.json"); err != nil {
+			return fmt.Errorf("failed to write standings: %v", err)
+		}
+		infof("✅ Saved standings to standings.json")
+	}
+
+	if *output.writeCSV {
+		file, err := os.Create("standings.csv")
+		if err != nil {
+			return fmt.Errorf("failed to write standings: %v", err)
+		}
+		defer file.Close()
+		if err := writeStandingsCSV(teams, file); err != nil {
+			return fmt.Errorf("failed to write standings: %v", err)
+		}
+		infof("✅ Saved standings to standings.csv")
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"flag"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestCheckFlagConflicts(t *testing.T) {
+	tests := []struct {
+		args    []string
+		wantErr string
+	}{
+		{[]string{"-print", "-team", "Team A"}, ""},
+		{[]string{"-serve", "-csv"}, "incompatible flags: -serve with -csv"},
+		{[]string{"-print", "-json", "-csv"}, "-print with -json, -print with -csv"},
+		{[]string{"-v", "-quiet"}, "-v with -quiet"},
+		{[]string{"-tournaments", "t.json", "-serve"}, ""},
+		{[]string{"-tournaments", "t.json", "-csv", "-snapshot"}, "-tournaments with -csv, -tournaments with -snapshot"},
+		{[]string{"-tournaments", "t.json", "-team", "Team A", "-out", "x.html"}, "-tournaments with -out, -tournaments with -team"},
+		{[]string{"-tournaments", "t.json", "-print"}, "-tournaments with -print"},
+		{[]string{"-interval", "1m", "-print"}, "-interval with -print"},
+	}
+	for _, tt := range tests {
+		fs := flag.NewFlagSet("pga-tracker", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		addCommonFlags(fs)
+		addScoreFlags(fs)
+		addOutputFlags(fs)
+		fs.Bool("serve", false, "")
+		fs.Duration("interval", 0, "")
+		fs.String("tournaments", "", "")
+		if err := fs.Parse(tt.args); err != nil {
+			t.Fatal(err)
+		}
+
+		err := checkFlagConflicts(fs)
+		switch {
+		case tt.wantErr == "" && err != nil:
+			t.Errorf("%v: %v", tt.args, err)
+		case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+			t.Errorf("%v: error = %v, want %q", tt.args, err, tt.wantErr)
+		}
+	}
+}
+
+func TestScoreFlagsRoundWeights(t *testing.T) {
+	for _, tt := range []struct {
+		args    []string
+		weights []int
+		wantErr string
+	}{
+		{nil, []int{1, 1, 1, 2}, ""},
+		{[]string{"-event-rounds", "3"}, []int{1, 1, 1, 2}, "has 4 weights but the event has 3 rounds"},
+		{nil, []int{1, 1, -1, 1}, "can't be negative"},
+		{[]string{"-trust-total"}, []int{1, 1, 1, 2}, "-trust-total can't be used"},
+	} {
+		fs := flag.NewFlagSet("pga-tracker", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		scoring := addScoreFlags(fs)
+		if err := fs.Parse(append(tt.args, "-overrides", "testdata/none.json")); err != nil {
+			t.Fatal(err)
+		}
+		score, err := scoring.apply(&Config{RoundWeights: tt.weights}, "")
+		switch {
+		case tt.wantErr == "" && (err != nil || len(score.RoundWeights) != 4):
+			t.Errorf("%v %v: weights %v, error %v", tt.args, tt.weights, score.RoundWeights, err)
+		case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+			t.Errorf("%v %v: error = %v, want %q", tt.args, tt.weights, err, tt.wantErr)
+		}
+	}
+}
+
+func TestScoreFlagsCount(t *testing.T) {
+	for _, tt := range []struct {
+		count   string
+		wantErr bool
+	}{
+		{"4", false},
+		{"1", false},
+		{"0", true},
+		{"-2", true},
+	} {
+		fs := flag.NewFlagSet("pga-tracker", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		scoring := addScoreFlags(fs)
+		if err := fs.Parse([]string{"-count", tt.count, "-overrides", "testdata/none.json"}); err != nil {
+			t.Fatal(err)
+		}
+		_, err := scoring.apply(&Config{}, "")
+		if (err != nil) != tt.wantErr {
+			t.Errorf("-count %s: error = %v, want error %v", tt.count, err, tt.wantErr)
+		}
+	}
+}
+
+func TestScoreFlagsScoring(t *testing.T) {
+	for _, tt := range []struct {
+		scoring string
+		wantErr bool
+	}{
+		{"topar", false},
+		{"strokes", true},
+	} {
+		fs := flag.NewFlagSet("pga-tracker", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		scoring := addScoreFlags(fs)
+		if err := fs.Parse([]string{"-scoring", tt.scoring, "-overrides", "testdata/none.json"}); err != nil {
+			t.Fatal(err)
+		}
+		_, err := scoring.apply(&Config{}, "")
+		if (err != nil) != tt.wantErr {
+			t.Errorf("-scoring %s: error = %v, want error %v", tt.scoring, err, tt.wantErr)
+		}
+	}
+}
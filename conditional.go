@@ -0,0 +1,66 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+)
+
+// cacheValidators are the freshness validators from the last leaderboard
+// response, sent back as conditional headers so an unchanged leaderboard
+// comes back as a bodyless 304.
+type cacheValidators struct {
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+}
+
+// validatorsPath returns the sidecar file holding validators for the
+// leaderboard saved at outPath.
+func validatorsPath(outPath string) string {
+	return outPath + ".etag"
+}
+
+// loadValidators reads the validators stored at path. A missing or corrupt
+// sidecar yields no validators, so the next fetch is unconditional.
+func loadValidators(path string) cacheValidators {
+	var v cacheValidators
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return v
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		warnf("Ignoring unreadable cache validators %s: %v", path, err)
+		return cacheValidators{}
+	}
+	return v
+}
+
+// saveValidators stores res's ETag and Last-Modified at path, removing the
+// sidecar when the response carried neither.
+func saveValidators(path string, res *http.Response) error {
+	v := cacheValidators{
+		ETag:         res.Header.Get("ETag"),
+		LastModified: res.Header.Get("Last-Modified"),
+	}
+	if v == (cacheValidators{}) {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// apply adds the conditional request headers for v to req.
+func (v cacheValidators) apply(req *http.Request) {
+	if v.ETag != "" {
+		req.Header.Set("If-None-Match", v.ETag)
+	}
+	if v.LastModified != "" {
+		req.Header.Set("If-Modified-Since", v.LastModified)
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"net/http"
+	"path/filepath"
+	"testing"
+)
+
+func TestCacheValidators(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "leaderboard.json.etag")
+	if v := loadValidators(path); v != (cacheValidators{}) {
+		t.Fatalf("loadValidators with no sidecar = %+v, want empty", v)
+	}
+
+	res := &http.Response{Header: http.Header{}}
+	res.Header.Set("ETag", `"abc123"`)
+	res.Header.Set("Last-Modified", "Sun, 26 Jul 2026 14:00:00 GMT")
+	if err := saveValidators(path, res); err != nil {
+		t.Fatalf("saveValidators: %v", err)
+	}
+
+	req, _ := http.NewRequest("GET", "https://example.com/leaderboard", nil)
+	loadValidators(path).apply(req)
+	if got := req.Header.Get("If-None-Match"); got != `"abc123"` {
+		t.Errorf("If-None-Match = %q, want %q", got, `"abc123"`)
+	}
+	if got := req.Header.Get("If-Modified-Since"); got != "Sun, 26 Jul 2026 14:00:00 GMT" {
+		t.Errorf("If-Modified-Since = %q", got)
+	}
+
+	// A response without validators clears the sidecar.
+	if err := saveValidators(path, &http.Response{Header: http.Header{}}); err != nil {
+		t.Fatalf("saveValidators: %v", err)
+	}
+	if v := loadValidators(path); v != (cacheValidators{}) {
+		t.Errorf("loadValidators after clearing = %+v, want empty", v)
+	}
+}
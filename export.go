@@ -0,0 +1,95 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"text/tabwriter"
+)
+
+// TeamStanding is a scored team as written to standings.json.
+type TeamStanding struct {
+	Team
+	GrandTotal int `json:"grandTotal"`
+}
+
+// standings pairs each of teams with its grand total, preserving order.
+func standings(teams []Team) []TeamStanding {
+	out := make([]TeamStanding, len(teams))
+	for i, t := range teams {
+		out[i] = TeamStanding{Team: t, GrandTotal: teamGrandTotal(t)}
+	}
+	return out
+}
+
+// writeStandingsJSON writes the scored teams, in standing order, to path.
+func writeStandingsJSON(teams []Team, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return encodeStandings(teams, file)
+}
+
+// encodeStandings writes the standings for teams to w as indented JSON. It's
+// shared by the standings.json export and the /api/standings endpoint.
+func encodeStandings(teams []Team, w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(standings(teams))
+}
+
+// writeStandingsCSV writes one row per player, including each team's Total
+// row, to w. Scores are integers relative to par, and excluded is true for
+// any player who doesn't count, including those scratched in the team file.
+func writeStandingsCSV(teams []Team, w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"team", "player", "R1", "R2", "R3", "R4", "total", "excluded"}); err != nil {
+		return err
+	}
+	for _, t := range teams {
+		for _, p := range t.PlayerScores {
+			record := []string{
+				t.TeamName,
+				p.FullName,
+				strconv.Itoa(p.R1),
+				strconv.Itoa(p.R2),
+				strconv.Itoa(p.R3),
+				strconv.Itoa(p.R4),
+				strconv.Itoa(p.Total),
+				strconv.FormatBool(p.Excluded || p.Scratched),
+			}
+			if err := cw.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeStandingsText writes an aligned plain-text table of the standings to
+// w. Players who don't count toward their team total get a trailing "*".
+func writeStandingsText(teams []Team, w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "TEAM\tPLAYER\tR1\tR2\tR3\tR4\tTOTAL")
+	for i, t := range teams {
+		if i > 0 {
+			fmt.Fprintln(tw, "\t\t\t\t\t\t")
+		}
+		for _, p := range t.PlayerScores {
+			name := p.FullName
+			if p.Excluded || p.Scratched {
+				name += "*"
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				t.TeamName, name, p.Round(1), p.Round(2), p.Round(3), p.Round(4), formatToPar(p.Total))
+		}
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteStandingsCSV(t *testing.T) {
+	teams := []Team{{
+		TeamName: "Team A",
+		PlayerScores: []Player{
+			{FullName: "Adam One", R1: -3, R2: -2, Total: -5},
+			{FullName: "Ben Two", R1: 1, Total: 1, Excluded: true},
+			{FullName: "Carl Three", R1: -4, Total: -4, Scratched: true},
+			{FullName: "Total", R1: -3, R2: -2, Total: -5},
+		},
+	}}
+
+	var buf bytes.Buffer
+	if err := writeStandingsCSV(teams, &buf); err != nil {
+		t.Fatalf("writeStandingsCSV: %v", err)
+	}
+
+	want := "team,player,R1,R2,R3,R4,total,excluded\n" +
+		"Team A,Adam One,-3,-2,0,0,-5,false\n" +
+		"Team A,Ben Two,1,0,0,0,1,true\n" +
+		"Team A,Carl Three,-4,0,0,0,-4,true\n" +
+		"Team A,Total,-3,-2,0,0,-5,false\n"
+	if got := buf.String(); got != want {
+		t.Errorf("CSV =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestWriteStandingsText(t *testing.T) {
+	teams := []Team{{
+		TeamName: "Team A",
+		PlayerScores: []Player{
+			{FullName: "Adam One", R1: -3, Total: -3, RoundsScored: 1},
+			{FullName: "Ben Two", R1: 1, Total: 1, RoundsScored: 1, Excluded: true},
+			{FullName: "Total", R1: -3, Total: -3, RoundsScored: 1},
+		},
+	}}
+
+	var buf bytes.Buffer
+	if err := writeStandingsText(teams, &buf); err != nil {
+		t.Fatalf("writeStandingsText: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{"Adam One  ", "Ben Two*", "Total", "-3"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Adam One*") {
+		t.Errorf("counted player marked excluded:\n%s", out)
+	}
+}
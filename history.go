@@ -0,0 +1,193 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Snapshot records the standings at one point in a tournament. Snapshots are
+// stored one JSON object per line in history/<tournId>.jsonl.
+type Snapshot struct {
+	Time  time.Time      `json:"time"`
+	Teams []SnapshotTeam `json:"teams"`
+}
+
+type SnapshotTeam struct {
+	TeamName string           `json:"teamName"`
+	Rank     int              `json:"rank"`
+	Total    int              `json:"total"`
+	Players  []SnapshotPlayer `json:"players,omitempty"`
+}
+
+// SnapshotPlayer is one pick's part in a team's snapshot. Counted is false
+// for players excluded or scratched from the team total.
+type SnapshotPlayer struct {
+	Name    string `json:"name"`
+	Total   int    `json:"total"`
+	Counted bool   `json:"counted"`
+}
+
+// historyPath returns where snapshots for tournID are kept.
+func historyPath(tournID string) string {
+	return filepath.Join("history", tournID+".jsonl")
+}
+
+// newSnapshot captures teams, which must already be in standing order.
+func newSnapshot(teams []Team, now time.Time) Snapshot {
+	snap := Snapshot{Time: now}
+	for i, t := range teams {
+		st := SnapshotTeam{
+			TeamName: t.TeamName,
+			Rank:     i + 1,
+			Total:    teamGrandTotal(t),
+		}
+		for _, p := range t.PlayerScores {
+			if p.FullName == "Total" {
+				continue
+			}
+			st.Players = append(st.Players, SnapshotPlayer{
+				Name:    p.FullName,
+				Total:   p.Total,
+				Counted: !p.Excluded && !p.Scratched,
+			})
+		}
+		snap.Teams = append(snap.Teams, st)
+	}
+	return snap
+}
+
+// appendSnapshot appends the current standings of teams to the history file
+// at path, creating it and its directory if needed.
+func appendSnapshot(path string, teams []Team) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return json.NewEncoder(file).Encode(newSnapshot(teams, time.Now()))
+}
+
+// lastSnapshot returns the most recent snapshot in the history file at
+// path. ok is false if the file doesn't exist or is empty.
+func lastSnapshot(path string) (snap Snapshot, ok bool, err error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return Snapshot{}, false, nil
+	}
+	if err != nil {
+		return Snapshot{}, false, err
+	}
+
+	lines := bytes.Split(bytes.TrimSpace(data), []byte("\n"))
+	last := lines[len(lines)-1]
+	if len(last) == 0 {
+		return Snapshot{}, false, nil
+	}
+	if err := json.Unmarshal(last, &snap); err != nil {
+		return Snapshot{}, false, fmt.Errorf("%s: %v", path, err)
+	}
+	return snap, true, nil
+}
+
+// orderChanged reports whether teams are ranked differently than in prev.
+func orderChanged(teams []Team, prev []SnapshotTeam) bool {
+	if len(teams) != len(prev) {
+		return true
+	}
+	for i, t := range teams {
+		if t.TeamName != prev[i].TeamName {
+			return true
+		}
+	}
+	return false
+}
+
+// setRankDeltas sets each team's PrevRank and RankDelta from prev. Teams
+// missing from prev are left with a zero PrevRank.
+func setRankDeltas(teams []Team, prev []SnapshotTeam) {
+	prevRanks := make(map[string]int, len(prev))
+	for _, p := range prev {
+		prevRanks[p.TeamName] = p.Rank
+	}
+	for i := range teams {
+		if rank, ok := prevRanks[teams[i].TeamName]; ok {
+			teams[i].PrevRank = rank
+			teams[i].RankDelta = rank - (i + 1)
+		}
+	}
+}
+
+// loadRankDeltas sets each team's movement since the last snapshot in the
+// history file at path. A missing or unreadable history leaves teams
+// unchanged, so every team shows as new.
+func loadRankDeltas(path string, teams []Team) {
+	prev, ok, err := lastSnapshot(path)
+	if err != nil {
+		warnf("Couldn't load previous standings: %v", err)
+		return
+	}
+	if ok {
+		setRankDeltas(teams, prev.Teams)
+	}
+}
+
+// RankMovement describes t's rank change as "up", "down", or "steady", or
+// "new" when there's no previous snapshot to compare against.
+func (t Team) RankMovement() string {
+	switch {
+	case t.PrevRank == 0:
+		return "new"
+	case t.RankDelta > 0:
+		return "up"
+	case t.RankDelta < 0:
+		return "down"
+	}
+	return "steady"
+}
+
+// RankArrow renders t's rank change for the scoreboard, e.g. "▲2" or "▼1".
+func (t Team) RankArrow() string {
+	switch t.RankMovement() {
+	case "new":
+		return "–"
+	case "up":
+		return fmt.Sprintf("▲%d", t.RankDelta)
+	case "down":
+		return fmt.Sprintf("▼%d", -t.RankDelta)
+	}
+	return "▬"
+}
+
+// recordStandings appends teams to the history at path. With any of notify
+// set, it first compares against the previous snapshot and posts to those
+// chats if the order changed.
+func recordStandings(path string, teams []Team, notify Notifiers) error {
+	if notify.any() {
+		prev, ok, err := lastSnapshot(path)
+		if err != nil {
+			return err
+		}
+		if ok && orderChanged(teams, prev.Teams) {
+			if notify.Discord {
+				if err := notifyDiscord(teams, prev.Teams); err != nil {
+					warnf("Failed to notify Discord: %v", err)
+				}
+			}
+			if notify.Slack {
+				if err := notifySlack(teams, notify.Tournament); err != nil {
+					warnf("Failed to notify Slack: %v", err)
+				}
+			}
+		}
+	}
+	return appendSnapshot(path, teams)
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAppendSnapshot(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history", "525.jsonl")
+	teams := []Team{
+		{TeamName: "Team A", PlayerScores: []Player{{FullName: "Total", Total: -10}}},
+		{TeamName: "Team B", PlayerScores: []Player{{FullName: "Total", Total: -4}}},
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := appendSnapshot(path, teams); err != nil {
+			t.Fatalf("appendSnapshot: %v", err)
+		}
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	var lines int
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lines++
+		var snap Snapshot
+		if err := json.Unmarshal(scanner.Bytes(), &snap); err != nil {
+			t.Fatalf("line %d: %v", lines, err)
+		}
+		if len(snap.Teams) != 2 || snap.Teams[1].TeamName != "Team B" || snap.Teams[1].Rank != 2 || snap.Teams[1].Total != -4 {
+			t.Errorf("line %d teams = %+v", lines, snap.Teams)
+		}
+	}
+	if lines != 2 {
+		t.Errorf("got %d snapshots, want 2", lines)
+	}
+}
+
+func TestOrderChanged(t *testing.T) {
+	teams := []Team{{TeamName: "Team A"}, {TeamName: "Team B"}}
+	tests := []struct {
+		name string
+		prev []SnapshotTeam
+		want bool
+	}{
+		{"same order", []SnapshotTeam{{TeamName: "Team A"}, {TeamName: "Team B"}}, false},
+		{"swapped", []SnapshotTeam{{TeamName: "Team B"}, {TeamName: "Team A"}}, true},
+		{"team added", []SnapshotTeam{{TeamName: "Team A"}}, true},
+	}
+	for _, tt := range tests {
+		if got := orderChanged(teams, tt.prev); got != tt.want {
+			t.Errorf("%s: orderChanged = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSetRankDeltas(t *testing.T) {
+	teams := []Team{{TeamName: "Team C"}, {TeamName: "Team A"}, {TeamName: "Team B"}, {TeamName: "Team D"}}
+	prev := []SnapshotTeam{
+		{TeamName: "Team A", Rank: 1},
+		{TeamName: "Team B", Rank: 3},
+		{TeamName: "Team C", Rank: 2},
+	}
+	setRankDeltas(teams, prev)
+
+	want := []string{"▲1", "▼1", "▬", "–"}
+	for i, w := range want {
+		if got := teams[i].RankArrow(); got != w {
+			t.Errorf("%s RankArrow = %q, want %q", teams[i].TeamName, got, w)
+		}
+	}
+}
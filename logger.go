@@ -0,0 +1,44 @@
+package main
+
+import (
+	"log"
+	"os"
+)
+
+type logLevel int
+
+const (
+	levelDebug logLevel = iota
+	levelInfo
+	levelWarn
+	levelError
+)
+
+var levelPrefixes = map[logLevel]string{
+	levelDebug: "DEBUG ",
+	levelInfo:  "INFO  ",
+	levelWarn:  "WARN  ",
+	levelError: "ERROR ",
+}
+
+// minLogLevel is the least severe level that gets written. -v lowers it to
+// debug and -quiet raises it to warn.
+var minLogLevel = levelInfo
+
+func logf(level logLevel, format string, args ...any) {
+	if level < minLogLevel {
+		return
+	}
+	log.Printf(levelPrefixes[level]+format, args...)
+}
+
+func debugf(format string, args ...any) { logf(levelDebug, format, args...) }
+func infof(format string, args ...any)  { logf(levelInfo, format, args...) }
+func warnf(format string, args ...any)  { logf(levelWarn, format, args...) }
+func errorf(format string, args ...any) { logf(levelError, format, args...) }
+
+// fatalf logs at error level and exits.
+func fatalf(format string, args ...any) {
+	errorf(format, args...)
+	os.Exit(1)
+}
@@ -1,38 +1,403 @@
 package main
 
 import (
+	"bytes"
+	"cmp"
+	"context"
+	"embed"
 	"encoding/json"
-	"flag"
 	"fmt"
 	"html/template"
 	"io"
-	"log"
+	"maps"
+	"math"
 	"net/http"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"syscall"
 	"time"
+	"unicode"
 )
 
 var (
-	members   = []string{"Matt", "JR", "Pat", "Alex", "Chuck"}
+	orgID     = "1"
 	tournID   = "525"
+	tournYear = "2026"
 	tournName = "3M Open"
+
+	// nameOverrides maps a team-file name to the {first, last} split the
+	// leaderboard uses, for names the simple first-space split gets wrong.
+	// Entries from the config file's nameOverrides are merged in at startup.
+	nameOverrides = map[string][2]string{
+		"Min Woo Lee": {"Min Woo", "Lee"},
+		"Si Woo Kim":  {"Si Woo", "Kim"},
+	}
+)
+
+type Config struct {
+	Members       []string             `json:"members,omitempty"`
+	TeamsDir      string               `json:"teamsDir"`
+	Leaderboard   string               `json:"leaderboard"`
+	Strict        bool                 `json:"strict"`
+	NameOverrides map[string][2]string `json:"nameOverrides"`
+	Composition   CompositionRules     `json:"composition"`
+
+	// LastPlaceLabel tags the team in last place on the scoreboard,
+	// defaulting to defaultLastPlaceLabel.
+	LastPlaceLabel string `json:"lastPlaceLabel"`
+
+	// RoundWeights multiplies each round's score in every total, e.g.
+	// [1, 1, 1, 2] to count the final round double. It needs one weight
+	// per event round; empty weights every round 1.
+	RoundWeights []int `json:"roundWeights,omitempty"`
+
+	// RosterLock is the roster manifest checked by checkRosterLock. It's
+	// set from the tournament being scored, not the config file, and
+	// empty disables the check. RosterLockReadOnly checks against an
+	// existing manifest without ever writing one, for runs such as -print
+	// that shouldn't leave files behind.
+	RosterLock         string `json:"-"`
+	RosterLockReadOnly bool   `json:"-"`
+}
+
+// CompositionRules are draft constraints on the tiers a team's players come
+// from. MaxPerTier caps how many players a team may have from each tier,
+// e.g. {"1": 1} allows a single top-tier pick.
+type CompositionRules struct {
+	MaxPerTier map[int]int `json:"maxPerTier"`
+}
+
+// ScoreOptions holds the pool's scoring rules.
+type ScoreOptions struct {
+	// Count is how many of a team's lowest totals are summed.
+	Count int
+	// CutPenalty is added to the cut line to get the score assigned to
+	// each round a CUT, WD, or DQ player won't play.
+	CutPenalty int
+	// CutMode is how those rounds are handled: cutModePenalty (the
+	// default when empty) scores them with CutPenalty, cutModeZero scores
+	// them as even, and cutModeDrop leaves them unscored and drops the
+	// player from the team's count.
+	CutMode string
+	// Project fills in each player's Projected finish.
+	Project bool
+	// Rounds lists the one-based rounds summed into each player's Total.
+	// Empty means all four count.
+	Rounds []int
+	// WhatIf maps player names to simulated R4 scores that replace the
+	// real ones. Nil means no simulation.
+	WhatIf map[string]int
+	// ByPosition lists each team's players in leaderboard order instead of
+	// by total. It doesn't change which players count.
+	ByPosition bool
+	// Overrides are commissioner corrections applied over the feed's
+	// round scores.
+	Overrides []ScoreOverride
+	// EventRounds is how many rounds the event has, from 1 to the four
+	// that Player can hold. Zero means four.
+	EventRounds int
+	// RankRound, when set, ranks teams by their counted players' scores
+	// in that one-based round alone. It doesn't change which players
+	// count.
+	RankRound int
+	// SinceCut ranks teams by WeekendTotal, their counted players' R3 and
+	// R4 scores with anyone who missed the cut left out. Like RankRound,
+	// it doesn't change which players count.
+	SinceCut bool
+	// TrustTotal scores players by the leaderboard's total instead of
+	// summing their rounds, plus any missed-round fills. Players with a
+	// score override still have their rounds summed.
+	TrustTotal bool
+	// RoundWeights are the config's per-round multipliers, one per event
+	// round, or nil to weight every round 1.
+	RoundWeights []int
+}
+
+// roundWeight returns how many times round n (one-based) counts in totals.
+func (o ScoreOptions) roundWeight(n int) int {
+	if n < 1 || n > len(o.RoundWeights) {
+		return 1
+	}
+	return o.RoundWeights[n-1]
+}
+
+// weighted reports whether any round counts other than once.
+func (o ScoreOptions) weighted() bool {
+	return slices.ContainsFunc(o.RoundWeights, func(w int) bool { return w != 1 })
+}
+
+// eventRounds returns o.EventRounds, defaulting to four.
+func (o ScoreOptions) eventRounds() int {
+	if o.EventRounds <= 0 || o.EventRounds > 4 {
+		return 4
+	}
+	return o.EventRounds
+}
+
+// scoringToPar is the -scoring mode: every score, including the team's
+// Total row and the rounds a cut player misses, is relative to par.
+const scoringToPar = "topar"
+
+const (
+	cutModePenalty = "penalty"
+	cutModeZero    = "zero"
+	cutModeDrop    = "drop"
 )
 
+// countsRound reports whether round n (one-based) counts toward totals.
+func (o ScoreOptions) countsRound(n int) bool {
+	return len(o.Rounds) == 0 || slices.Contains(o.Rounds, n)
+}
+
+// parseRounds parses a -rounds value like "3,4" into round numbers,
+// rejecting anything outside 1-4. An empty string means all rounds.
+func parseRounds(s string) ([]int, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	var rounds []int
+	for _, field := range strings.Split(s, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || n < 1 || n > 4 {
+			return nil, fmt.Errorf("invalid round %q: rounds must be 1-4", strings.TrimSpace(field))
+		}
+		if !slices.Contains(rounds, n) {
+			rounds = append(rounds, n)
+		}
+	}
+	return rounds, nil
+}
+
+// FetchOptions controls which leaderboard fetchLeaderboard requests and how
+// it talks to the API.
+type FetchOptions struct {
+	OrgID   string
+	TournID string
+	Year    string
+	Timeout time.Duration
+	Retries int
+	MaxAge  time.Duration
+	OutPath string
+
+	// APIKeyFile, if set, holds the RapidAPI key in place of the
+	// RAPID_GOLF_API_KEY environment variable.
+	APIKeyFile string
+
+	// RatePerMinute caps API requests per minute across runs; a fetch
+	// over the limit keeps the cached leaderboard. Zero means no limit.
+	RatePerMinute int
+
+	// SourceURL, if set, is fetched in place of the RapidAPI leaderboard
+	// and must decode as a Leaderboard. Headers are added to the request,
+	// e.g. for the feed's auth; the RapidAPI key isn't sent.
+	SourceURL string
+	Headers   http.Header
+
+	// Force fetches even when the cache is younger than MaxAge and skips
+	// the conditional headers, so a full leaderboard is always saved.
+	// RatePerMinute still applies.
+	Force bool
+}
+
 type PageData struct {
 	Teams       []Team
 	LastUpdated string
 	CurrentYear int
 	TournName   string
+	TournCourse string
+	TournDates  string
+
+	// Tournament is the full event metadata, including its status.
+	Tournament TournamentMeta
+
+	// ShowProjection adds the projected-finish column.
+	ShowProjection bool
+
+	// Skipped names the teams left off the page because they failed to load.
+	Skipped []string
+
+	// ShowHoles adds birdie and eagle counts, set when any player has
+	// hole-by-hole data.
+	ShowHoles bool
+
+	// Unmatched summarizes players missing from the leaderboard, per team.
+	Unmatched []string
+
+	// Layout is layoutTable or layoutCompact; empty renders the table.
+	Layout string
+
+	// RankRound is the round teams are ranked by, or 0 for the overall
+	// standings. SinceCut marks the weekend view instead.
+	RankRound int
+	SinceCut  bool
+
+	// ShowNet adds handicap and gross columns, set when any team uses
+	// handicaps.
+	ShowNet bool
+
+	// RoundWeights are the per-round multipliers behind every total, set
+	// only when some round counts other than once.
+	RoundWeights []int
+
+	// ProjectedCut is the cut line expected after R2, or nil once the cut
+	// is made or when it can't be projected.
+	ProjectedCut *int
+
+	// Matchups holds this week's head-to-head results when a pairings
+	// file is given.
+	Matchups []Matchup
+
+	// LowRound is the best completed round by any player, or nil before
+	// anyone has finished a round.
+	LowRound *LowRound
+
+	// Cut explains how missed rounds were scored, or is nil before the
+	// cut line is posted.
+	Cut *CutLine
+}
+
+// CutLine is the tournament's cut and what it means for scoring: Score is
+// the cut line, and under cutModePenalty or cutModeZero each round a CUT,
+// WD, or DQ player misses counts as MissedRound. Under cutModeDrop they
+// don't count at all.
+type CutLine struct {
+	Score       int
+	MissedRound int
+	Mode        string
+}
+
+// cutLine describes meta's cut line as scored under score, or returns nil
+// if no cut has been posted.
+func cutLine(meta TournamentMeta, score ScoreOptions) *CutLine {
+	if meta.CutScore == "" {
+		return nil
+	}
+	cut := &CutLine{Score: parseCutScore(meta.CutScore), Mode: score.CutMode}
+	switch score.CutMode {
+	case cutModeZero, cutModeDrop:
+	default:
+		cut.Mode = cutModePenalty
+		cut.MissedRound = cut.Score + score.CutPenalty
+	}
+	return cut
+}
+
+// LowRound is the low round of the week and everyone who shot it.
+type LowRound struct {
+	Score   int
+	Players []string
+}
+
+// lowRound finds the best completed round across every team's players. A
+// player picked by two teams is only listed once.
+func lowRound(teams []Team) *LowRound {
+	var low *LowRound
+	for _, t := range teams {
+		for _, p := range t.PlayerScores {
+			if p.FullName == "Total" || p.RoundsPlayed == 0 {
+				continue
+			}
+			switch {
+			case low == nil || p.BestRound < low.Score:
+				low = &LowRound{Score: p.BestRound, Players: []string{p.FullName}}
+			case p.BestRound == low.Score && !slices.Contains(low.Players, p.FullName):
+				low.Players = append(low.Players, p.FullName)
+			}
+		}
+	}
+	return low
+}
+
+// WeightSummary describes p.RoundWeights like "R1 ×1, R2 ×1, R3 ×1, R4 ×2".
+func (p PageData) WeightSummary() string {
+	parts := make([]string, len(p.RoundWeights))
+	for i, w := range p.RoundWeights {
+		parts[i] = fmt.Sprintf("R%d ×%d", i+1, w)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ViewTotal is t's total in the page's view: the RankRound's scores, the
+// weekend's with SinceCut, or the grand total.
+func (p PageData) ViewTotal(t Team) int {
+	if total := (ScoreOptions{RankRound: p.RankRound, SinceCut: p.SinceCut}).viewTotal(); total != nil {
+		return total(t)
+	}
+	return teamGrandTotal(t)
+}
+
+// TotalTeams is the number of teams in the pool, including skipped ones.
+func (p PageData) TotalTeams() int {
+	return len(p.Teams) + len(p.Skipped)
 }
 
 type Team struct {
+	// Version is the team file format version, between 1 and
+	// teamFileVersion. Files written before versioning leave it out and
+	// read as version 1.
+	Version int `json:"version,omitempty"`
+
 	TeamName     string       `json:"teamName"`
 	Players      []string     `json:"players"`
-	PlayerScores []Player     `json:"-"`
+	Excluded     []string     `json:"excluded,omitempty"`
+	PlayerScores []Player     `json:"playerScores,omitempty"`
 	Tournaments  []Tournament `json:"tournaments"`
+
+	// Tiers optionally maps each player to their draft tier, checked
+	// against the pool's CompositionRules.
+	Tiers map[string]int `json:"tiers,omitempty"`
+
+	// Handicaps optionally maps players to strokes taken off their total.
+	Handicaps map[string]int `json:"handicaps,omitempty"`
+
+	// PlayerIDs optionally maps players to their leaderboard playerId, to
+	// tell apart golfers who share a name.
+	PlayerIDs map[string]string `json:"playerIds,omitempty"`
+
+	// Color and Emoji personalize the team on the scoreboard. Color is a
+	// CSS color such as "#1e90ff" or "gold". Unset, they fall back to
+	// defaultTeamColor and defaultTeamEmoji.
+	Color string `json:"color,omitempty"`
+	Emoji string `json:"emoji,omitempty"`
+
+	// Substitutes stand in, in order, for players who withdraw before the
+	// tournament or aren't in the field.
+	Substitutes []string `json:"substitutes,omitempty"`
+
+	// ActiveCount and CutCount split the team's players between those
+	// still in the tournament and those who are CUT, WD, or DQ. Players
+	// scratched in the team file aren't counted.
+	ActiveCount int `json:"activeCount"`
+	CutCount    int `json:"cutCount"`
+
+	// BehindLeader is how many strokes the team trails the leader by.
+	BehindLeader int `json:"behindLeader"`
+
+	// LastPlace is the label shown on a team in last place, tied or
+	// alone, and empty for every other team.
+	LastPlace string `json:"lastPlace,omitempty"`
+
+	// BubbleMargin is how many strokes the last counted player leads the
+	// best excluded one by, or nil when no player is excluded.
+	BubbleMargin *int `json:"bubbleMargin,omitempty"`
+
+	// Unmatched lists the team's players that weren't found on the
+	// leaderboard, usually because of a misspelling in the team file.
+	Unmatched []string `json:"unmatched,omitempty"`
+
+	// PrevRank is the team's one-based rank in the previous snapshot, or
+	// 0 if there isn't one. RankDelta is how many places it has climbed
+	// since then; negative means it dropped.
+	PrevRank  int `json:"prevRank,omitempty"`
+	RankDelta int `json:"rankDelta,omitempty"`
 }
 
 type Tournament struct {
@@ -80,290 +445,2182 @@ func (t Team) NonMajors() []Tournament {
 	return out
 }
 
-type Player struct {
-	FullName string `json:"name"`
-	R1       int    `json:"r1"`
-	R2       int    `json:"r2"`
-	R3       int    `json:"r3"`
-	R4       int    `json:"r4"`
-	Total    int    `json:"total"`
-	Excluded bool
-}
-
-type Round struct {
-	Strokes string `json:"scoreToPar"`
+// teamGrandTotal returns the total from the "Total" row that getTeamScores
+// appends to a team's PlayerScores.
+func teamGrandTotal(t Team) int {
+	return teamTotalRow(t).Total
 }
 
-type LeaderboardRow struct {
-	FirstName string  `json:"firstName"`
-	LastName  string  `json:"lastName"`
-	Total     string  `json:"total"`
-	Rounds    []Round `json:"rounds"`
-	Position  string  `json:"position"`
-	RoundComplete bool    `json:"roundComplete"`
-	CurrentRoundScore string `json:"currentRoundScore"`
+// sortTeams orders teams by standing using compareTeams.
+func sortTeams(teams []Team) {
+	slices.SortStableFunc(teams, compareTeams)
 }
 
-type Leaderboard struct {
-	CutLines []struct {
-		CutScore string `json:"cutScore"`
-	} `json:"cutLines"`
-	LeaderboardRows []LeaderboardRow `json:"leaderboardRows"`
+// teamRoundTotal returns what t's counted players scored in round n.
+func teamRoundTotal(t Team, n int) int {
+	return teamTotalRow(t).RoundScore(n)
 }
 
-func main() {
-	refresh := flag.Bool("refresh", false, "Fetch latest leaderboard from API")
-	flag.Parse()
-
-	if *refresh {
-		err := fetchLeaderboard()
-		if err != nil {
-			log.Fatalf("Failed to refresh leaderboard: %v", err)
+// WeekendTotal returns what t's counted players who made the cut scored in
+// the rounds after it, R3 and R4. Cut players' penalty rounds aren't part
+// of it, so it shows how the survivors did on the weekend.
+func (t Team) WeekendTotal() int {
+	total := 0
+	for _, p := range t.PlayerScores {
+		if p.FullName == "Total" || p.Excluded || p.Scratched || p.Status != "" {
+			continue
 		}
-		log.Println("✅ Fetched latest leaderboard")
+		total += p.R3 + p.R4
 	}
-   teams := make([]Team, len(members))
-   for i, member := range members {
-	   teamData, err := loadTeam(fmt.Sprintf("teams/%s.json", member))
-	   if err != nil {
-		   log.Fatal(err)
-	   }
-
-	   playerScores, err := getTeamScores("leaderboard.json", teamData.Players)
-	   if err != nil {
-		   log.Fatal(err)
-	   }
-
-	   teams[i] = teamData
-	   teams[i].PlayerScores = playerScores
-   }
+	return total
+}
 
-   err := renderScoreboard(teams)
-   if err != nil {
-	   log.Fatalf("render failed: %v", err)
-   }
+// sortTeamsBy orders teams by total, breaking ties with compareTeams.
+func sortTeamsBy(teams []Team, total func(Team) int) {
+	slices.SortStableFunc(teams, func(a, b Team) int {
+		if c := cmp.Compare(total(a), total(b)); c != 0 {
+			return c
+		}
+		return compareTeams(a, b)
+	})
 }
 
-func getTeamScores(filePath string, teamNames []string) ([]Player, error) {
-	file, err := os.Open(filePath)
-	if err != nil {
-		return nil, err
+// viewTotal returns what teams are ranked by in the view opts selects:
+// one round's scores with RankRound, the weekend with SinceCut, or nil for
+// the overall standings.
+func (o ScoreOptions) viewTotal() func(Team) int {
+	switch {
+	case o.RankRound > 0:
+		return func(t Team) int { return teamRoundTotal(t, o.RankRound) }
+	case o.SinceCut:
+		return Team.WeekendTotal
 	}
-	defer file.Close()
+	return nil
+}
 
-	var leaderboard Leaderboard
-	if err := json.NewDecoder(file).Decode(&leaderboard); err != nil {
-		return nil, err
+// rankTeams sorts teams by standing and sets how far each trails the
+// leader. In a round or since-cut view, both go by that view's scores alone.
+func rankTeams(teams []Team, opts ScoreOptions) {
+	total := opts.viewTotal()
+	if total == nil {
+		sortTeams(teams)
+		setBehindLeader(teams)
+		return
 	}
+	sortTeamsBy(teams, total)
+	for i := range teams {
+		teams[i].BehindLeader = total(teams[i]) - total(teams[0])
+	}
+}
 
-	cutVal := 0
-	if len(leaderboard.CutLines) > 0 {
-		cutVal = parseCutScore(leaderboard.CutLines[0].CutScore) + 3
+// compareTeams orders two teams by the league's standings rules:
+//
+//  1. Lower grand total.
+//  2. Lower single round by any counted player.
+//  3. Lower team total in the final round (R4).
+//  4. Team name, so the order is always deterministic.
+func compareTeams(a, b Team) int {
+	if c := cmp.Compare(teamGrandTotal(a), teamGrandTotal(b)); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(lowestCountedRound(a), lowestCountedRound(b)); c != 0 {
+		return c
 	}
+	if c := cmp.Compare(teamTotalRow(a).R4, teamTotalRow(b).R4); c != 0 {
+		return c
+	}
+	return strings.Compare(a.TeamName, b.TeamName)
+}
 
-	var team []Player
-	for _, name := range teamNames {
-		firstName, lastName := splitName(name)
-		var found *LeaderboardRow
-		for _, row := range leaderboard.LeaderboardRows {
-			if row.FirstName == firstName && row.LastName == lastName {
-				found = &row
-				break
-			}
+// teamTotalRow returns the "Total" row getTeamScores appends to a team.
+func teamTotalRow(t Team) Player {
+	for _, p := range t.PlayerScores {
+		if p.FullName == "Total" {
+			return p
 		}
-		if found == nil {
-			log.Printf("Player not found in leaderboard: %s", name)
+	}
+	return Player{}
+}
+
+// lowestCountedRound returns the best single round among t's counted
+// players, or math.MaxInt if none has a scored round.
+func lowestCountedRound(t Team) int {
+	lowest := math.MaxInt
+	for _, p := range t.PlayerScores {
+		if p.FullName == "Total" || p.Excluded || p.Scratched {
 			continue
 		}
-
-		player := Player{FullName: name}
-		isCut := strings.ToUpper(found.Position) == "CUT"
-
-		numRounds := len(found.Rounds)
-		if !found.RoundComplete {
-			numRounds++
-		}
-		for i := 0; i < 4; i++ {
-			if i < numRounds && !isCut {
-				var strokesString string
-				if !found.RoundComplete && i == numRounds-1 {
-					strokesString = found.CurrentRoundScore
-				} else {
-					strokesString = found.Rounds[i].Strokes
-				}
-				strokes := strokesInt(strokesString)
-				switch i {
-				case 0:
-					player.R1 = strokes
-				case 1:
-					player.R2 = strokes
-				case 2:
-					player.R3 = strokes
-				case 3:
-					player.R4 = strokes
-				}
-			} else if isCut && i >= 2 {
-				switch i {
-				case 2:
-					player.R3 = cutVal
-				case 3:
-					player.R4 = cutVal
-				}
+		rounds := [4]int{p.R1, p.R2, p.R3, p.R4}
+		for i, r := range rounds[:min(p.RoundsScored, 4)] {
+			if !slices.Contains(p.Unplayed, i+1) {
+				lowest = min(lowest, r)
 			}
 		}
-  if len(found.Rounds) == 0 {
-	player.R1 = strokesInt(found.Total)
-  }
-		player.Total = player.R1 + player.R2 + player.R3 + player.R4
-		team = append(team, player)
 	}
+	return lowest
+}
 
-	sort.Slice(team, func(i, j int) bool {
-		return team[i].Total < team[j].Total
-	})
+// Player holds one golfer's scores. R1-R4 and Total are strokes relative to
+// par, matching the leaderboard's scoreToPar values.
+type Player struct {
+	FullName string `json:"name"`
+	R1       int    `json:"r1"`
+	R2       int    `json:"r2"`
+	R3       int    `json:"r3"`
+	R4       int    `json:"r4"`
+	Total    int    `json:"total"`
+	Position string `json:"position,omitempty"`
+	Status   string `json:"status,omitempty"`
+	Excluded bool   `json:"excluded"`
 
-	for i := 4; i < len(team); i++ {
-		team[i].Excluded = true
-	}
+	// Rank is the numeric leaderboard position from parsePosition, or 0
+	// for players without one, such as CUT. Tied marks a "T" position.
+	Rank int  `json:"rank,omitempty"`
+	Tied bool `json:"tied,omitempty"`
 
-	r1Total, r2Total, r3Total, r4Total, grandTotal := 0, 0, 0, 0, 0
-	for _, p := range team[:4] {
-		r1Total += p.R1
-		r2Total += p.R2
-		r3Total += p.R3
-		r4Total += p.R4
-		grandTotal += p.Total
+	// Scratched marks a player listed in the team file's excluded list,
+	// or one out of the tournament under cutModeDrop. Unlike Excluded,
+	// which getTeamScores sets for players outside the counting top N, a
+	// scratched player never counts regardless of score.
+	Scratched bool `json:"scratched,omitempty"`
+
+	// RoundsScored is how many rounds, counting from R1, hold a score.
+	RoundsScored int `json:"roundsScored"`
+
+	// Unplayed lists the one-based rounds before RoundsScored that the
+	// leaderboard left as a placeholder, such as a round moved for
+	// weather. They score zero but aren't a real round of even par.
+	Unplayed []int `json:"unplayed,omitempty"`
+
+	// Cumulative holds the running total after each round, so
+	// Cumulative[1] is R1+R2.
+	Cumulative [4]int `json:"cumulative"`
+
+	// Projected is the estimated final total from projectTotal, set only
+	// when projections are enabled.
+	Projected int `json:"projected,omitempty"`
+
+	// InProgress is set while the player is on the course; the latest
+	// scored round is then partial, Today to par through hole Thru.
+	InProgress bool   `json:"inProgress,omitempty"`
+	Thru       string `json:"thru,omitempty"`
+	Today      int    `json:"today,omitempty"`
+
+	// HolesPlayed, Birdies, Eagles, and Bogeys tally the player's
+	// hole-by-hole scoring. They stay zero when the leaderboard has no
+	// per-hole data. Eagles includes anything better than an eagle and
+	// Bogeys anything worse than a bogey.
+	HolesPlayed int `json:"holesPlayed,omitempty"`
+	Birdies     int `json:"birdies,omitempty"`
+	Eagles      int `json:"eagles,omitempty"`
+	Bogeys      int `json:"bogeys,omitempty"`
+
+	// Average is the mean of the rounds the player has completed, and
+	// Trend is "improving", "declining", or "steady" across them. Cut
+	// penalties and the live round don't count, and Trend needs at least
+	// two completed rounds.
+	Average float64 `json:"average,omitempty"`
+	Trend   string  `json:"trend,omitempty"`
+
+	// RoundsPlayed counts the completed rounds, which come before any
+	// fills for rounds a player won't play and the live round. Neither of
+	// those is included, and nor are Unplayed gaps. BestRound and
+	// WorstRound are from bestWorstRound.
+	RoundsPlayed int `json:"roundsPlayed"`
+	BestRound    int `json:"bestRound"`
+	WorstRound   int `json:"worstRound"`
+
+	// Gross is the total before Handicap is taken off; Total is net. For
+	// the team's Total row, Handicap sums the counted players'.
+	Gross    int `json:"gross"`
+	Handicap int `json:"handicap,omitempty"`
+
+	// FeedTotal is the to-par total the leaderboard reports, which can
+	// disagree with the rounds when one is missing from the feed. It's
+	// nil when the leaderboard has no usable total.
+	FeedTotal *int `json:"feedTotal,omitempty"`
+
+	// SubstituteFor names the pick this player replaced, if any.
+	SubstituteFor string `json:"substituteFor,omitempty"`
+
+	// ProjectedCut is the cut line expected after R2, set only while the
+	// cut is still to come and one can be projected; InsideCut is then
+	// whether the player is at or better than it.
+	ProjectedCut *int `json:"projectedCut,omitempty"`
+	InsideCut    bool `json:"insideCut,omitempty"`
+
+	// TeeTime is when the player tees off in round TeeRound, or nil when
+	// the leaderboard has no tee time for them. TeeRound is 0 if the
+	// round isn't known.
+	TeeTime  *time.Time `json:"teeTime,omitempty"`
+	TeeRound int        `json:"teeRound,omitempty"`
+}
+
+// bestWorstRound returns p's lowest and highest completed round, or 0, 0 if
+// they haven't completed one.
+func bestWorstRound(p Player) (int, int) {
+	if p.RoundsPlayed == 0 {
+		return 0, 0
+	}
+	best, worst := math.MaxInt, math.MinInt
+	for n, seen := 1, 0; n <= 4 && seen < p.RoundsPlayed; n++ {
+		if slices.Contains(p.Unplayed, n) {
+			continue
+		}
+		best = min(best, p.RoundScore(n))
+		worst = max(worst, p.RoundScore(n))
+		seen++
 	}
+	return best, worst
+}
 
-	total := Player{
-		FullName: "Total",
-		R1:       r1Total,
-		R2:       r2Total,
-		R3:       r3Total,
-		R4:       r4Total,
-		Total:    grandTotal,
+// scoringTrend returns the mean of played, which holds completed rounds in
+// order, and whether the player is improving or declining in them. The
+// trend follows the sum of round-over-round changes, so a lower score each
+// round reads as improving.
+func scoringTrend(played []int) (avg float64, trend string) {
+	if len(played) == 0 {
+		return 0, ""
 	}
-	team = append(team, total)
+	sum, change := 0, 0
+	for i, r := range played {
+		sum += r
+		if i > 0 {
+			change += r - played[i-1]
+		}
+	}
+	avg = float64(sum) / float64(len(played))
+	switch {
+	case len(played) < 2:
+	case change < 0:
+		trend = "improving"
+	case change > 0:
+		trend = "declining"
+	default:
+		trend = "steady"
+	}
+	return avg, trend
+}
 
-	return team, nil
+// tallyHoles adds the per-hole scoring in rounds to p's birdie, eagle, and
+// bogey counts. Holes with no strokes recorded haven't been played yet and
+// are skipped.
+func (p *Player) tallyHoles(rounds []Round) {
+	for _, r := range rounds {
+		for _, h := range r.Holes {
+			if h.Strokes <= 0 {
+				continue
+			}
+			p.HolesPlayed++
+			switch diff := h.Strokes - h.Par; {
+			case diff <= -2:
+				p.Eagles++
+			case diff == -1:
+				p.Birdies++
+			case diff >= 1:
+				p.Bogeys++
+			}
+		}
+	}
 }
 
+// Round formats round n (one-based) relative to par, or "-" if the round
+// hasn't been scored yet.
+func (p Player) Round(n int) string {
+	if n > p.RoundsScored || slices.Contains(p.Unplayed, n) {
+		return "-"
+	}
+	if p.InProgress && n == p.RoundsScored {
+		return fmt.Sprintf("thru %s (%s)", p.Thru, formatToPar(p.Today))
+	}
+	if n < 1 || n > 4 {
+		return "-"
+	}
+	return formatToPar(p.RoundScore(n))
+}
 
+// playerTotal sums p's rounds that count toward totals under opts, each
+// multiplied by its round weight. It's how getTeamScores sets each player's
+// Gross total unless opts.TrustTotal says to take the leaderboard's; the
+// team's Total row sums its players'.
+func playerTotal(p Player, opts ScoreOptions) int {
+	total := 0
+	for n := 1; n <= 4; n++ {
+		if opts.countsRound(n) {
+			total += opts.roundWeight(n) * p.RoundScore(n)
+		}
+	}
+	return total
+}
 
-func splitName(name string) (string, string) {
-	switch name {
-	case "Min Woo Lee":
-		return "Min Woo", "Lee"
-	case "Si Woo Kim":
-		return "Si Woo", "Kim"
+// withdrewEarly reports whether row, a pick's leaderboard row, calls for a
+// substitute: the pick isn't on the leaderboard at all, or withdrew or was
+// disqualified without playing a round.
+func withdrewEarly(row *LeaderboardRow) bool {
+	if row == nil {
+		return true
 	}
-	split := strings.SplitN(name, " ", 2)
-	if len(split) != 2 {
-		log.Printf("Skipping invalid name: %s", name)
+	switch playerStatus(row.Position) {
+	case "WD", "DQ":
+		return len(row.Rounds) == 0
 	}
-	firstName, lastName := split[0], split[1]
-	return firstName, lastName
+	return false
 }
 
-func parseCutScore(cut string) int {
-	val := strings.TrimPrefix(cut, "+")
-	val = strings.TrimPrefix(val, "-")
-	var score int
-	fmt.Sscanf(val, "%d", &score)
-	return score
+// missingReason describes why withdrewEarly was true for row.
+func missingReason(row *LeaderboardRow) string {
+	if row == nil {
+		return "isn't on the leaderboard"
+	}
+	return "is " + playerStatus(row.Position) + " before playing a round"
 }
 
-func loadTeam(filePath string) (Team, error) {
-	file, err := os.Open(filePath)
-	if err != nil {
-		return Team{}, err
+// findRow returns the leaderboard row for the player picked as name, or nil
+// if there isn't one. With id set, only the row with that playerId matches.
+// Without one, the first row with the name wins, with a warning when other
+// golfers share it.
+func findRow(rows []LeaderboardRow, name, id string) *LeaderboardRow {
+	if id != "" {
+		for i := range rows {
+			if rows[i].PlayerID == id {
+				return &rows[i]
+			}
+		}
+		warnf("No leaderboard row has playerId %s (%s)", id, name)
+		return nil
 	}
-	defer file.Close()
 
-	var team Team
-	if err := json.NewDecoder(file).Decode(&team); err != nil {
-		return Team{}, err
+	firstName, lastName := splitName(name)
+	firstName, lastName = normalizeName(firstName), normalizeName(lastName)
+	var matches []*LeaderboardRow
+	for i := range rows {
+		if normalizeName(rows[i].FirstName) == firstName && normalizeName(rows[i].LastName) == lastName {
+			matches = append(matches, &rows[i])
+		}
 	}
-	return team, nil
+	if len(matches) == 0 {
+		return nil
+	}
+	if len(matches) > 1 {
+		warnf("%d leaderboard rows match %s; scoring playerId %s (add a playerIds entry to pick another)", len(matches), name, matches[0].PlayerID)
+	}
+	return matches[0]
 }
 
-func fetchLeaderboard() error {
-	apiKey := os.Getenv("RAPID_GOLF_API_KEY")
-
-	url := fmt.Sprintf("https://live-golf-data.p.rapidapi.com/leaderboard?orgId=1&tournId=%s&year=2026", tournID)
+// playerIDFor looks up name in playerIDs, matching names the same way the
+// leaderboard is matched.
+func playerIDFor(playerIDs map[string]string, name string) string {
+	id, _ := lookupName(playerIDs, name)
+	return id
+}
 
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		log.Fatalf("Failed to create request: %v", err)
-	}
+// netTotal returns p's gross total less a handicap of hcp strokes.
+func netTotal(p Player, hcp int) int {
+	return p.Gross - hcp
+}
 
-	req.Header.Add("x-rapidapi-key", apiKey)
-	req.Header.Add("x-rapidapi-host", "live-golf-data.p.rapidapi.com")
+// handicapFor looks up name in handicaps, matching names the same way the
+// leaderboard is matched. Players without an entry play off scratch.
+func handicapFor(handicaps map[string]int, name string) int {
+	hcp, _ := lookupName(handicaps, name)
+	return hcp
+}
 
-	res, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to make request: %v", err)
+// lookupName returns the value in m keyed by a player name matching name
+// the way the leaderboard is matched. Keys are tried in sorted order, so if
+// two spellings of one player are both listed, the same one always wins.
+func lookupName[V any](m map[string]V, name string) (V, bool) {
+	want := normalizeName(name)
+	for _, n := range slices.Sorted(maps.Keys(m)) {
+		if normalizeName(n) == want {
+			return m[n], true
+		}
 	}
-	defer res.Body.Close()
+	var zero V
+	return zero, false
+}
 
-	if res.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected status code: %d %s", res.StatusCode, res.Status)
+// RoundScore returns the raw score for round n (one-based).
+func (p Player) RoundScore(n int) int {
+	switch n {
+	case 1:
+		return p.R1
+	case 2:
+		return p.R2
+	case 3:
+		return p.R3
+	case 4:
+		return p.R4
 	}
+	return 0
+}
 
-	body, err := io.ReadAll(res.Body)
-	if err != nil {
-		return fmt.Errorf("Failed to read response body: %v", err)
+// AfterRound formats the running total through round n (one-based), or
+// "-" if round n hasn't been scored yet.
+func (p Player) AfterRound(n int) string {
+	if n < 1 || n > 4 || n > p.RoundsScored {
+		return "-"
 	}
+	return formatToPar(p.Cumulative[n-1])
+}
 
-	// Optional: Pretty-print JSON to a file
-	var prettyJSON map[string]interface{}
-	if err := json.Unmarshal(body, &prettyJSON); err != nil {
-		return fmt.Errorf("Failed to parse JSON: %v", err)
+// setCumulative fills Cumulative with running totals of R1-R4. They're the
+// rounds as played, without RoundWeights, since the cut goes by them.
+func (p *Player) setCumulative() {
+	p.Cumulative[0] = p.R1
+	p.Cumulative[1] = p.Cumulative[0] + p.R2
+	p.Cumulative[2] = p.Cumulative[1] + p.R3
+	p.Cumulative[3] = p.Cumulative[2] + p.R4
+}
+
+// setRound stores strokes as the score for round i (zero-based).
+func (p *Player) setRound(i, strokes int) {
+	p.RoundsScored = max(p.RoundsScored, i+1)
+	switch i {
+	case 0:
+		p.R1 = strokes
+	case 1:
+		p.R2 = strokes
+	case 2:
+		p.R3 = strokes
+	case 3:
+		p.R4 = strokes
 	}
+}
 
-	file, err := os.Create("leaderboard.json")
-	if err != nil {
-		return fmt.Errorf("Failed to create file: %v", err)
+// playerStatus maps a leaderboard position to "CUT", "WD", or "DQ" for
+// players no longer in the tournament, and "" for everyone else.
+func playerStatus(position string) string {
+	switch strings.ToUpper(strings.TrimSpace(position)) {
+	case "CUT", "MC":
+		return "CUT"
+	case "WD":
+		return "WD"
+	case "DQ", "DSQ":
+		return "DQ"
 	}
-	defer file.Close()
+	return ""
+}
 
-	encoder := json.NewEncoder(file)
-	encoder.SetIndent("", "  ") // Pretty-print with indent
-	if err := encoder.Encode(prettyJSON); err != nil {
-		return fmt.Errorf("Failed to write JSON to file: %v", err)
+// parsePosition splits a leaderboard position like "T5" into its rank and
+// whether it's tied. Positions without a rank, such as "CUT" or "WD", return
+// a zero rank and the uppercased position as special.
+func parsePosition(position string) (rank int, tied bool, special string) {
+	p := strings.ToUpper(strings.TrimSpace(position))
+	digits := strings.TrimPrefix(p, "T")
+	if n, err := strconv.Atoi(digits); err == nil && n > 0 {
+		return n, digits != p, ""
 	}
+	return 0, false, p
+}
 
-	fmt.Println("✅ Saved leaderboard data to leaderboard.json")
-	return nil
+// sortByPosition orders players by leaderboard rank, with unranked players
+// after them in their existing order. A trailing Total row stays last.
+func sortByPosition(players []Player) {
+	if n := len(players); n > 0 && players[n-1].FullName == "Total" {
+		players = players[:n-1]
+	}
+	slices.SortStableFunc(players, func(a, b Player) int {
+		if (a.Rank == 0) != (b.Rank == 0) {
+			if a.Rank == 0 {
+				return 1
+			}
+			return -1
+		}
+		return cmp.Compare(a.Rank, b.Rank)
+	})
 }
 
-func strokesInt(s string) int {
-	strokes, _ := strconv.Atoi(s)
-	return strokes
+type Round struct {
+	RoundID    int    `json:"roundId"`
+	ScoreToPar string `json:"scoreToPar"`
+	CourseName string `json:"courseName"`
+
+	// Holes is only present when the feed includes per-hole scoring.
+	Holes []Hole `json:"holes,omitempty"`
 }
 
-func renderScoreboard(teams []Team) error {
-	tmpl := template.Must(template.New("scoreboard").Funcs(template.FuncMap{
-		"isTotal": func(name string) bool {
-			return name == "Total"
-		},
-	}).ParseFiles("templates/scoreboard.html"))
-	
+// Hole is one hole of a round's scorecard.
+type Hole struct {
+	HoleID  int `json:"holeId"`
+	Par     int `json:"par"`
+	Strokes int `json:"holeScore"`
+}
 
-	out, err := os.Create("docs/index.html")
-	if err != nil {
-		return err
-	}
-	defer out.Close()
+type LeaderboardRow struct {
+	FirstName         string  `json:"firstName"`
+	LastName          string  `json:"lastName"`
+	PlayerID          string  `json:"playerId"`
+	Total             string  `json:"total"`
+	Rounds            []Round `json:"rounds"`
+	Position          string  `json:"position"`
+	RoundComplete     bool    `json:"roundComplete"`
+	Thru              string  `json:"thru"`
+	CurrentRoundScore string  `json:"currentRoundScore"`
 
-	now := time.Now()
-	data := PageData{
-		Teams:       teams,
-		LastUpdated: now.Format("Jan 2, 2006 3:04PM MST"),
-		CurrentYear: now.Year(),
-		TournName:   tournName,
-	}
-	
+	// TeeTime is the player's next or current tee time as the feed shows
+	// it, e.g. "11:35am"; TeeTimeTimestamp is the same instant, which is
+	// what's used. CurrentRound is the round it's for.
+	TeeTime          string      `json:"teeTime"`
+	TeeTimeTimestamp epochMillis `json:"teeTimeTimestamp"`
+	CurrentRound     int         `json:"currentRound"`
+}
 
-	return tmpl.Execute(out, data)
+type Leaderboard struct {
+	TournID     string `json:"tournId"`
+	Year        string `json:"year"`
+	Name        string `json:"name"`
+	LastUpdated string `json:"lastUpdated"`
+	Status      string `json:"status"`
+	RoundID     int    `json:"roundId"`
+	CutLines    []struct {
+		CutScore string `json:"cutScore"`
+	} `json:"cutLines"`
+	LeaderboardRows []LeaderboardRow `json:"leaderboardRows"`
+}
+
+// TournamentMeta describes the event a leaderboard belongs to.
+type TournamentMeta struct {
+	Name   string
+	Course string
+	Dates  string
+
+	// Status is the event's state from parseTournamentStatus and Round
+	// the round being played.
+	Status string
+	Round  int
+
+	// CutScore is the posted cut line, e.g. "+3", or empty before there
+	// is one.
+	CutScore string
+}
+
+const (
+	statusUpcoming  = "upcoming"
+	statusLive      = "live"
+	statusSuspended = "suspended"
+	statusFinal     = "final"
+)
+
+// parseTournamentStatus interprets a leaderboard status such as "In
+// Progress" or "Official" as one of the status constants, or "" when it
+// isn't recognized.
+func parseTournamentStatus(status string) string {
+	switch strings.ToLower(strings.TrimSpace(status)) {
+	case "official", "final", "complete", "completed":
+		return statusFinal
+	case "in progress", "live", "playoff":
+		return statusLive
+	case "suspended", "delayed":
+		return statusSuspended
+	case "not started", "upcoming", "scheduled", "groupings official":
+		return statusUpcoming
+	}
+	return ""
+}
+
+// Final reports whether the event's results are official.
+func (m TournamentMeta) Final() bool {
+	return m.Status == statusFinal
+}
+
+// StatusBanner is the scoreboard's status line, e.g. "LIVE — Round 3", or
+// "" if the status is unknown.
+func (m TournamentMeta) StatusBanner() string {
+	round := ""
+	if m.Round > 0 {
+		round = fmt.Sprintf(" — Round %d", m.Round)
+	}
+	switch m.Status {
+	case statusFinal:
+		return "FINAL"
+	case statusLive:
+		return "LIVE" + round
+	case statusSuspended:
+		return "SUSPENDED" + round
+	case statusUpcoming:
+		return "UPCOMING"
+	}
+	return ""
+}
+
+// loadLeaderboard reads and decodes the leaderboard saved at filePath.
+func loadLeaderboard(filePath string) (*Leaderboard, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var leaderboard Leaderboard
+	if err := json.NewDecoder(file).Decode(&leaderboard); err != nil {
+		return nil, fmt.Errorf("%s: %v", filePath, err)
+	}
+	return &leaderboard, nil
+}
+
+// loadLeaderboardMeta reads the event details from the leaderboard at
+// filePath. The name and dates fall back to the cached schedule when the
+// leaderboard doesn't carry them.
+func loadLeaderboardMeta(filePath string) (TournamentMeta, error) {
+	leaderboard, err := loadLeaderboard(filePath)
+	if err != nil {
+		return TournamentMeta{}, err
+	}
+
+	meta := TournamentMeta{
+		Name:   leaderboard.Name,
+		Status: parseTournamentStatus(leaderboard.Status),
+		Round:  leaderboard.RoundID,
+	}
+	if len(leaderboard.CutLines) > 0 {
+		meta.CutScore = leaderboard.CutLines[0].CutScore
+	}
+	for _, row := range leaderboard.LeaderboardRows {
+		if len(row.Rounds) > 0 && row.Rounds[0].CourseName != "" {
+			meta.Course = row.Rounds[0].CourseName
+			break
+		}
+	}
+
+	if schedule, ok := cachedSchedule(leaderboard.Year); ok {
+		if info, ok := schedule.findTournament(leaderboard.TournID); ok {
+			if meta.Name == "" {
+				meta.Name = info.Name
+			}
+			meta.Dates = formatDateRange(info)
+		}
+	}
+	return meta, nil
+}
+
+// tournamentMeta is loadLeaderboardMeta for rendering: failures are logged
+// and the name falls back to fallbackName so the page always has a header.
+func tournamentMeta(leaderboardPath, fallbackName string) TournamentMeta {
+	meta, err := loadLeaderboardMeta(leaderboardPath)
+	if err != nil {
+		warnf("Couldn't read tournament details: %v", err)
+	}
+	if meta.Name == "" {
+		meta.Name = fallbackName
+	}
+	return meta
+}
+
+// formatDateRange renders an event's dates like "Jul 23-26, 2026", or ""
+// if the schedule dates don't parse.
+func formatDateRange(info TournamentInfo) string {
+	start, err := info.StartDate()
+	if err != nil {
+		return ""
+	}
+	end, err := info.EndDate()
+	if err != nil {
+		return ""
+	}
+	if start.Month() == end.Month() {
+		return fmt.Sprintf("%s %d-%d, %d", start.Format("Jan"), start.Day(), end.Day(), end.Year())
+	}
+	return fmt.Sprintf("%s - %s", start.Format("Jan 2"), end.Format("Jan 2, 2006"))
+}
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fatalf("%v", err)
+	}
+}
+
+// watch fetches, scores, and renders every interval until SIGINT or SIGTERM,
+// or until the leaderboard says the tournament is final. A failed cycle is
+// logged and retried on the next tick. Signals are only observed between
+// cycles, so an in-flight render always finishes. When snapshotPath is
+// set, each cycle's standings are recorded there as with recordStandings.
+func watch(interval time.Duration, opts FetchOptions, cfg Config, score ScoreOptions, outPath, snapshotPath string, notify Notifiers) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	for {
+		if err := fetchLeaderboard(opts); err != nil {
+			errorf("Failed to refresh leaderboard: %v", err)
+		}
+
+		if err := renderCycle(cfg, score, historyPath(opts.TournID), outPath, snapshotPath, notify); err != nil {
+			errorf("%v", err)
+		} else if tournamentMeta(cfg.Leaderboard, tournName).Final() {
+			infof("✅ Rendered final results; tournament is over, so no more refreshes")
+			return nil
+		} else {
+			infof("✅ Rendered scoreboard; next refresh in %s", interval)
+		}
+
+		select {
+		case <-ctx.Done():
+			infof("Shutting down")
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// renderCycle builds and renders the scoreboard once for watch, marking rank
+// movement against the history at movementPath and recording the standings
+// to snapshotPath when it's set.
+func renderCycle(cfg Config, score ScoreOptions, movementPath, outPath, snapshotPath string, notify Notifiers) error {
+	teams, skipped, err := buildTeams(cfg, score)
+	if err != nil {
+		return fmt.Errorf("failed to build teams: %v", err)
+	}
+	loadRankDeltas(movementPath, teams)
+	meta := tournamentMeta(cfg.Leaderboard, tournName)
+	if err := renderScoreboard(newPageData(teams, skipped, meta, score), outPath); err != nil {
+		return fmt.Errorf("render failed: %v", err)
+	}
+	if snapshotPath != "" {
+		notify.Tournament = meta.Name
+		if err := recordStandings(snapshotPath, teams, notify); err != nil {
+			return fmt.Errorf("failed to save snapshot: %v", err)
+		}
+	}
+	return nil
+}
+
+// buildTeams loads and scores every team in cfg, skipping any that fail with
+// a logged warning, and returns them sorted by standing along with the names
+// of the skipped teams.
+func buildTeams(cfg Config, score ScoreOptions) ([]Team, []string, error) {
+	members := cfg.Members
+	if len(members) == 0 {
+		var err error
+		if members, err = discoverTeams(cfg.TeamsDir); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var loaded []Team
+	var failures []string
+	for _, member := range members {
+		teamData, err := loadTeam(filepath.Join(cfg.TeamsDir, member+".json"))
+		if err != nil {
+			warnf("Skipping team %s: %v", member, err)
+			failures = append(failures, member)
+			continue
+		}
+		loaded = append(loaded, teamData)
+	}
+
+	var illegal []string
+	for _, t := range loaded {
+		if err := validateTeamComposition(t, cfg.Composition); err != nil {
+			illegal = append(illegal, fmt.Sprintf("%s: %v", t.TeamName, err))
+		}
+	}
+	if len(illegal) > 0 {
+		if cfg.Strict {
+			return nil, nil, fmt.Errorf("teams break the draft rules: %s", strings.Join(illegal, "; "))
+		}
+		for _, msg := range illegal {
+			warnf("Illegal roster for %s", msg)
+		}
+	}
+
+	if dupes := duplicatePicks(loaded); len(dupes) > 0 {
+		if cfg.Strict {
+			return nil, nil, fmt.Errorf("players picked by more than one team: %s", strings.Join(dupes, "; "))
+		}
+		for _, d := range dupes {
+			warnf("Picked by more than one team: %s", d)
+		}
+	}
+
+	leaderboard, err := loadLeaderboard(cfg.Leaderboard)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load leaderboard: %v", err)
+	}
+	scores := scoreTeams(leaderboard, loaded, score)
+
+	var teams []Team
+	for i, teamData := range loaded {
+		playerScores := scores[i]
+		debugf("Scoring %s", teamData.TeamName)
+		logBreakdown(playerScores, missedRoundScore(leaderboard, score), score)
+
+		teamData.PlayerScores = playerScores
+		teamData.Unmatched = unmatchedPicks(teamData.Players, playerScores)
+		teamData.ActiveCount, teamData.CutCount = playerCounts(playerScores)
+		teamData.BubbleMargin = bubbleMargin(playerScores)
+		teams = append(teams, teamData)
+	}
+
+	if len(failures) > 0 {
+		warnf("%d of %d teams failed to load: %s", len(failures), len(members), strings.Join(failures, ", "))
+	}
+	if cfg.RosterLock != "" {
+		changed, err := checkRosterLock(cfg.RosterLock, teams, playStarted(teams) && !cfg.RosterLockReadOnly)
+		if err != nil {
+			return nil, nil, fmt.Errorf("roster lock: %v", err)
+		}
+		if len(changed) > 0 {
+			if cfg.Strict {
+				return nil, nil, fmt.Errorf("rosters changed after the draft lock: %s", strings.Join(changed, ", "))
+			}
+			for _, name := range changed {
+				warnf("Roster for %s changed after the draft lock in %s", name, cfg.RosterLock)
+			}
+		}
+	}
+	if unmatched := unmatchedSummary(teams); len(unmatched) > 0 {
+		warnf("Players not found on the leaderboard: %s", strings.Join(unmatched, "; "))
+	}
+	if len(teams) == 0 {
+		return nil, failures, fmt.Errorf("no teams loaded")
+	}
+	debugf("Loaded %d of %d teams", len(teams), len(members))
+
+	rankTeams(teams, score)
+	setLastPlace(teams, cfg.LastPlaceLabel, score.viewTotal())
+	return teams, failures, nil
+}
+
+// scoreWorkers caps how many teams scoreTeams scores at once.
+var scoreWorkers = runtime.GOMAXPROCS(0)
+
+// scoreTeams runs getTeamScores for every team against leaderboard on a
+// pool of scoreWorkers goroutines, preparing the field only once. The
+// results are indexed like teams.
+func scoreTeams(leaderboard *Leaderboard, teams []Team, opts ScoreOptions) [][]Player {
+	scores := make([][]Player, len(teams))
+	f := newField(leaderboard, opts)
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range min(scoreWorkers, len(teams)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				scores[i] = scorePicks(f, teams[i], opts)
+			}
+		}()
+	}
+	for i := range teams {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return scores
+}
+
+// playerCounts returns how many of players are still active and how many
+// are out of the tournament, skipping the Total row and players scratched by
+// the team file.
+func playerCounts(players []Player) (active, cut int) {
+	for _, p := range players {
+		switch {
+		case p.FullName == "Total":
+		case p.Status != "":
+			cut++
+		case !p.Scratched:
+			active++
+		}
+	}
+	return active, cut
+}
+
+// bubbleMargin returns how far the worst counted player in scores is ahead
+// of the best one left out by -count, or nil if nobody was left out. Players
+// scratched by the team file or dropped by -cut-mode don't count as left
+// out.
+func bubbleMargin(scores []Player) *int {
+	counted, excluded := 0, 0
+	haveCounted, haveExcluded := false, false
+	for _, p := range scores {
+		switch {
+		case p.FullName == "Total" || p.Scratched:
+		case p.Excluded:
+			if !haveExcluded || p.Total < excluded {
+				excluded = p.Total
+			}
+			haveExcluded = true
+		default:
+			if !haveCounted || p.Total > counted {
+				counted = p.Total
+			}
+			haveCounted = true
+		}
+	}
+	if !haveCounted || !haveExcluded {
+		return nil
+	}
+	margin := excluded - counted
+	return &margin
+}
+
+// unmatchedPicks returns the players picked that have no row in scores,
+// meaning getTeamScores couldn't find them on the leaderboard. Picks a
+// substitute replaced aren't unmatched.
+func unmatchedPicks(picks []string, scores []Player) []string {
+	var missing []string
+	for _, name := range picks {
+		if !slices.ContainsFunc(scores, func(p Player) bool { return p.FullName == name || p.SubstituteFor == name }) {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+// unmatchedSummary describes each team's unmatched players, e.g.
+// "Team A: Tom Kim, Jason Day", in standing order.
+func unmatchedSummary(teams []Team) []string {
+	var summary []string
+	for _, t := range teams {
+		if len(t.Unmatched) > 0 {
+			summary = append(summary, t.TeamName+": "+strings.Join(t.Unmatched, ", "))
+		}
+	}
+	return summary
+}
+
+// findTeam returns the team in teams named name, ignoring case and accents.
+func findTeam(teams []Team, name string) (Team, error) {
+	var names []string
+	for _, t := range teams {
+		if normalizeName(t.TeamName) == normalizeName(name) {
+			return t, nil
+		}
+		names = append(names, t.TeamName)
+	}
+	return Team{}, fmt.Errorf("no team named %q; teams are: %s", name, strings.Join(names, ", "))
+}
+
+// setBehindLeader records how many strokes each team trails the first team
+// by. teams must already be sorted.
+func setBehindLeader(teams []Team) {
+	if len(teams) == 0 {
+		return
+	}
+	leader := teamGrandTotal(teams[0])
+	for i := range teams {
+		teams[i].BehindLeader = teamGrandTotal(teams[i]) - leader
+	}
+}
+
+// defaultLastPlaceLabel is the last-place tag when the config doesn't set one.
+const defaultLastPlaceLabel = "🥄 Last place"
+
+// setLastPlace tags every team tied for the highest total with label, or
+// defaultLastPlaceLabel if it's empty. total is the view's score, as from
+// ScoreOptions.viewTotal; nil uses the grand total. Nobody is last when
+// every team is tied, including when there's only one.
+func setLastPlace(teams []Team, label string, total func(Team) int) {
+	if label == "" {
+		label = defaultLastPlaceLabel
+	}
+	if total == nil {
+		total = teamGrandTotal
+	}
+	worst := math.MinInt
+	for _, t := range teams {
+		worst = max(worst, total(t))
+	}
+	var last []int
+	for i, t := range teams {
+		if total(t) == worst {
+			last = append(last, i)
+		}
+	}
+	if len(last) == len(teams) {
+		return
+	}
+	for _, i := range last {
+		teams[i].LastPlace = label
+	}
+}
+
+// duplicatePicks describes each player who appears on more than one team,
+// e.g. "Tom Kim (Team A, Team B)", sorted by player name.
+func duplicatePicks(teams []Team) []string {
+	pickedBy := map[string][]string{}
+	names := map[string]string{}
+	for _, t := range teams {
+		for _, p := range t.Players {
+			key := normalizeName(p)
+			if _, ok := names[key]; !ok {
+				names[key] = p
+			}
+			pickedBy[key] = append(pickedBy[key], t.TeamName)
+		}
+	}
+
+	var dupes []string
+	for key, teamNames := range pickedBy {
+		if len(teamNames) > 1 {
+			dupes = append(dupes, fmt.Sprintf("%s (%s)", names[key], strings.Join(teamNames, ", ")))
+		}
+	}
+	sort.Strings(dupes)
+	return dupes
+}
+
+// getTeamScores scores the players picked by picks against leaderboard. Only
+// the opts.Count lowest totals are summed into the trailing "Total" row; the
+// rest are marked Excluded. If the team has fewer than opts.Count players
+// found, all of them count. Players in picks.Excluded are still scored for
+// display but are marked Scratched, sorted last, and never count.
+//
+// A CUT, WD, or DQ player is scored the cut line plus opts.CutPenalty for
+// each round they won't play, so with a +2 cut and the default penalty of 3
+// every missed round counts as +5.
+//
+// A player with an entry in picks.Handicaps has it subtracted from their
+// total before the lowest totals are chosen, so every Total is net; Gross
+// keeps the unadjusted total. Players with an entry in picks.PlayerIDs are
+// matched by playerId rather than name.
+//
+// A pick who isn't on the leaderboard, or withdrew or was disqualified
+// before playing a round, is replaced by the next of picks.Substitutes,
+// who is then scored normally.
+//
+// getTeamScores only reads leaderboard, so teams can be scored from one
+// decoded copy concurrently.
+func getTeamScores(leaderboard *Leaderboard, picks Team, opts ScoreOptions) []Player {
+	return scorePicks(newField(leaderboard, opts), picks, opts)
+}
+
+// field is a leaderboard prepared for scoring: its rows and the field-wide
+// values every team's scores depend on, so they're worked out once per
+// leaderboard rather than once per team.
+type field struct {
+	rows []LeaderboardRow
+	// round is the leaderboard's current round.
+	round int
+	// missedRound is missedRoundScore, what each round a CUT, WD, or DQ
+	// player won't play counts as.
+	missedRound int
+	// average is the fieldAverage used for projections, set only when
+	// opts.Project is.
+	average float64
+	// cut is the projectCut line, when cutProjected.
+	cut          int
+	cutProjected bool
+}
+
+func newField(leaderboard *Leaderboard, opts ScoreOptions) field {
+	f := field{
+		rows:        leaderboard.LeaderboardRows,
+		round:       leaderboard.RoundID,
+		missedRound: missedRoundScore(leaderboard, opts),
+	}
+	f.cut, f.cutProjected = projectCut(leaderboard)
+	if opts.Project {
+		f.average = fieldAverage(leaderboard.LeaderboardRows)
+	}
+	return f
+}
+
+// scorePicks is getTeamScores against a prepared field: it finds each of
+// picks' players, or their substitute, scores them with scorePlayer, and
+// hands the results to selectTeam.
+func scorePicks(f field, picks Team, opts ScoreOptions) []Player {
+	var players []Player
+	substitutes := picks.Substitutes
+	for _, name := range picks.Players {
+		found := findRow(f.rows, name, playerIDFor(picks.PlayerIDs, name))
+		subFor := ""
+		if withdrewEarly(found) && len(substitutes) > 0 {
+			sub := substitutes[0]
+			substitutes = substitutes[1:]
+			infof("Substitute: %s replaces %s, who %s", sub, name, missingReason(found))
+			name, subFor = sub, name
+			found = findRow(f.rows, name, playerIDFor(picks.PlayerIDs, name))
+		}
+		if found == nil {
+			warnf("Player not found in leaderboard: %s", name)
+			metrics.playersNotFound.Add(1)
+			continue
+		}
+		player := scorePlayer(found, name, f, picks, opts)
+		player.SubstituteFor = subFor
+		players = append(players, player)
+	}
+	return selectTeam(players, opts)
+}
+
+// scorePlayer scores the pick name, whose leaderboard row is found, under
+// opts: their rounds, any fills for rounds they won't play, their totals,
+// and the team file's handicap and scratch for them. It doesn't decide
+// whether they count; that's selectTeam.
+func scorePlayer(found *LeaderboardRow, name string, f field, picks Team, opts ScoreOptions) Player {
+	player := Player{
+		FullName: name,
+		Position: found.Position,
+		Status:   playerStatus(found.Position),
+	}
+	player.Rank, player.Tied, _ = parsePosition(found.Position)
+	if tee := found.TeeTimeTimestamp.Time(); !tee.IsZero() {
+		player.TeeTime, player.TeeRound = &tee, cmp.Or(found.CurrentRound, f.round)
+	}
+	inactive := player.Status != ""
+	rounds := alignRounds(found.Rounds, opts.eventRounds(), name)
+
+	// Players who are out of the tournament keep the rounds they
+	// completed; every round they won't play is filled with f.missedRound.
+	numRounds := len(rounds)
+	if !found.RoundComplete && !inactive {
+		numRounds++
+	}
+	var completed []int
+	fills := 0
+	for i := 0; i < opts.eventRounds(); i++ {
+		switch {
+		case i < numRounds && !found.RoundComplete && !inactive && i == numRounds-1:
+			// The live round only counts once the player has teed off;
+			// until then it stays unscored rather than a phantom even.
+			thru := strings.TrimSuffix(found.Thru, "*")
+			if thru == "" || thru == "-" || found.CurrentRoundScore == "-" {
+				break
+			}
+			player.Today = parseToPar(found.CurrentRoundScore)
+			player.Thru = thru
+			player.InProgress = true
+			player.setRound(i, player.Today)
+		case i < numRounds && !placeholderRound(rounds[i]):
+			player.setRound(i, parseToPar(rounds[i].ScoreToPar))
+			completed = append(completed, i+1)
+		case i < numRounds && !inactive:
+			// A gap in the rounds hasn't been played yet; it stays
+			// out of the player's average and best round.
+			player.Unplayed = append(player.Unplayed, i+1)
+		case inactive && opts.CutMode == cutModeZero:
+			player.setRound(i, 0)
+		case inactive && opts.CutMode != cutModeDrop:
+			player.setRound(i, f.missedRound)
+			if opts.countsRound(i + 1) {
+				fills += f.missedRound
+			}
+		}
+	}
+	player.Unplayed = slices.DeleteFunc(player.Unplayed, func(n int) bool { return n > player.RoundsScored })
+	// An override can fill a gap, so the rounds played are only known
+	// once they're applied.
+	completed = append(completed, player.Unplayed...)
+	slices.Sort(completed)
+	applyOverrides(&player, opts.Overrides)
+	var played []int
+	for _, n := range completed {
+		if !slices.Contains(player.Unplayed, n) {
+			played = append(played, player.RoundScore(n))
+		}
+	}
+	if simulated, ok := opts.whatIfScore(name); ok && !inactive {
+		player.setRound(3, simulated)
+		player.InProgress, player.Thru, player.Today = false, "", 0
+	}
+	player.Average, player.Trend = scoringTrend(played)
+	player.RoundsPlayed = len(played)
+	player.BestRound, player.WorstRound = bestWorstRound(player)
+	player.tallyHoles(rounds)
+	if len(found.Rounds) == 0 {
+		if total, err := parseScore(found.Total); err != nil {
+			warnf("Couldn't parse total for %s: %v", name, err)
+		} else {
+			player.setRound(0, total)
+		}
+	}
+	player.Gross = playerTotal(player, opts)
+	if total, err := parseScore(found.Total); err == nil && len(found.Rounds) > 0 {
+		player.FeedTotal = &total
+		if opts.TrustTotal && !hasOverride(name, opts.Overrides) {
+			if summed := player.Gross - fills; summed != total {
+				debugf("%s: rounds sum to %s but the leaderboard total is %s; using the total", name, formatToPar(summed), formatToPar(total))
+			}
+			player.Gross = total + fills
+		}
+	}
+	player.Handicap = handicapFor(picks.Handicaps, name)
+	player.Total = netTotal(player, player.Handicap)
+	player.setCumulative()
+	if f.cutProjected && !inactive {
+		standing := player.Cumulative[1]
+		if player.FeedTotal != nil {
+			standing = *player.FeedTotal
+		}
+		cut := f.cut
+		player.ProjectedCut = &cut
+		player.InsideCut = standing <= cut
+	}
+	if opts.Project {
+		player.Projected = projectTotal(player, f.average, opts.eventRounds())
+	}
+	for _, s := range picks.Excluded {
+		if normalizeName(s) == normalizeName(name) {
+			player.Scratched = true
+		}
+	}
+	if inactive && opts.CutMode == cutModeDrop {
+		player.Scratched = true
+	}
+	return player
+}
+
+// selectTeam picks which of a team's scored players count: the opts.Count
+// lowest totals among those not Scratched, or all of them if there are
+// fewer. The rest are marked Excluded. It sorts team in place, best first
+// or in leaderboard order with opts.ByPosition, and returns it with the
+// team's "Total" row appended. It needs nothing but the players, so it's
+// easy to test without a leaderboard.
+func selectTeam(team []Player, opts ScoreOptions) []Player {
+	count := opts.Count
+
+	// Stable, so players tied on total keep their team file order and the
+	// same one is excluded every run.
+	sort.SliceStable(team, func(i, j int) bool {
+		if team[i].Scratched != team[j].Scratched {
+			return !team[i].Scratched
+		}
+		return team[i].Total < team[j].Total
+	})
+
+	eligible := 0
+	for _, p := range team {
+		if !p.Scratched {
+			eligible++
+		}
+	}
+	if count > eligible {
+		count = eligible
+	}
+	for i := count; i < eligible; i++ {
+		team[i].Excluded = true
+	}
+
+	r1Total, r2Total, r3Total, r4Total := 0, 0, 0, 0
+	roundsScored, projected, gross, handicap := 0, 0, 0, 0
+	holes, birdies, eagles, bogeys := 0, 0, 0, 0
+	for _, p := range team[:count] {
+		gross += p.Gross
+		handicap += p.Handicap
+		roundsScored = max(roundsScored, p.RoundsScored)
+		projected += p.Projected
+		holes += p.HolesPlayed
+		birdies += p.Birdies
+		eagles += p.Eagles
+		bogeys += p.Bogeys
+		r1Total += p.R1
+		r2Total += p.R2
+		r3Total += p.R3
+		r4Total += p.R4
+	}
+
+	total := Player{
+		FullName: "Total",
+		R1:       r1Total,
+		R2:       r2Total,
+		R3:       r3Total,
+		R4:       r4Total,
+
+		RoundsScored: roundsScored,
+		Projected:    projected,
+		HolesPlayed:  holes,
+		Birdies:      birdies,
+		Eagles:       eagles,
+		Bogeys:       bogeys,
+	}
+	total.Gross = gross
+	total.Handicap = handicap
+	total.Total = netTotal(total, handicap)
+	total.setCumulative()
+	team = append(team, total)
+
+	if opts.ByPosition {
+		sortByPosition(team)
+	}
+
+	return team
+}
+
+// diacriticFolds maps lowercase accented letters to their plain ASCII
+// spelling so "Joaquín" and "Joaquin" compare equal.
+var diacriticFolds = map[rune]string{
+	'à': "a", 'á': "a", 'â': "a", 'ã': "a", 'ä': "a", 'å': "a", 'ā': "a", 'ă': "a", 'ą': "a",
+	'ç': "c", 'ć': "c", 'ĉ': "c", 'ċ': "c", 'č': "c",
+	'ď': "d", 'đ': "d", 'ð': "d",
+	'è': "e", 'é': "e", 'ê': "e", 'ë': "e", 'ē': "e", 'ĕ': "e", 'ė': "e", 'ę': "e", 'ě': "e",
+	'ĝ': "g", 'ğ': "g", 'ġ': "g", 'ģ': "g",
+	'ĥ': "h", 'ħ': "h",
+	'ì': "i", 'í': "i", 'î': "i", 'ï': "i", 'ĩ': "i", 'ī': "i", 'ĭ': "i", 'į': "i", 'ı': "i",
+	'ĵ': "j", 'ķ': "k",
+	'ĺ': "l", 'ļ': "l", 'ľ': "l", 'ŀ': "l", 'ł': "l",
+	'ñ': "n", 'ń': "n", 'ņ': "n", 'ň': "n",
+	'ò': "o", 'ó': "o", 'ô': "o", 'õ': "o", 'ö': "o", 'ø': "o", 'ō': "o", 'ŏ': "o", 'ő': "o",
+	'ŕ': "r", 'ŗ': "r", 'ř': "r",
+	'ś': "s", 'ŝ': "s", 'ş': "s", 'š': "s", 'ș': "s",
+	'ţ': "t", 'ť': "t", 'ŧ': "t", 'ț': "t",
+	'ù': "u", 'ú': "u", 'û': "u", 'ü': "u", 'ũ': "u", 'ū': "u", 'ŭ': "u", 'ů': "u", 'ű': "u", 'ų': "u",
+	'ŵ': "w", 'ý': "y", 'ÿ': "y", 'ŷ': "y",
+	'ź': "z", 'ż': "z", 'ž': "z",
+	'ß': "ss", 'æ': "ae", 'œ': "oe", 'þ': "th",
+}
+
+// normalizeName folds a name into a form suitable for matching: lowercase,
+// accents stripped, parenthetical notes such as an amateur's "(a)" removed,
+// punctuation dropped, hyphens treated as spaces, and runs of whitespace
+// collapsed.
+func normalizeName(name string) string {
+	var b strings.Builder
+	depth := 0
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r == '(':
+			depth++
+			continue
+		case r == ')' && depth > 0:
+			depth--
+			continue
+		case depth > 0:
+			continue
+		}
+		if fold, ok := diacriticFolds[r]; ok {
+			b.WriteString(fold)
+			continue
+		}
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		case unicode.IsSpace(r) || r == '-':
+			b.WriteRune(' ')
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// splitName returns the leaderboard first and last name for name, using
+// nameOverrides when present and otherwise splitting on the first space.
+func splitName(name string) (string, string) {
+	if split, ok := nameOverrides[name]; ok {
+		return split[0], split[1]
+	}
+	split := strings.SplitN(strings.TrimSpace(name), " ", 2)
+	if len(split) != 2 {
+		return split[0], ""
+	}
+	return split[0], split[1]
+}
+
+// fieldAverage returns the mean to-par score of every completed round on
+// the leaderboard, or 0 if no rounds have been completed. Placeholder
+// rounds haven't been played and are left out.
+func fieldAverage(rows []LeaderboardRow) float64 {
+	sum, n := 0, 0
+	for _, row := range rows {
+		for _, r := range row.Rounds {
+			if placeholderRound(r) {
+				continue
+			}
+			sum += parseToPar(r.ScoreToPar)
+			n++
+		}
+	}
+	if n == 0 {
+		return 0
+	}
+	return float64(sum) / float64(n)
+}
+
+// projectTotal estimates p's final total over an event of the given number
+// of rounds by scoring every round they haven't started at fieldAvg. A round
+// in progress counts as played, so this is a rough heuristic that's most
+// useful early in the tournament.
+func projectTotal(p Player, fieldAvg float64, rounds int) int {
+	remaining := rounds - p.RoundsScored + len(p.Unplayed)
+	if remaining <= 0 {
+		return p.Total
+	}
+	return p.Total + int(math.Round(float64(remaining)*fieldAvg))
+}
+
+// placeholderRound reports whether r is an entry the leaderboard holds for
+// a round that hasn't been played, with a score of "", "-", or similar
+// rather than a real one.
+func placeholderRound(r Round) bool {
+	_, err := parseScore(r.ScoreToPar)
+	return err != nil
+}
+
+// alignRounds returns a player's rounds in order, keeping at most maxRounds.
+// Scoring assumes a standard event of at most four rounds, so anything past
+// that (a playoff entry or a feed glitch) is logged and dropped. When every
+// round carries a roundId, each round goes in the slot for its ID so a stray
+// entry can't shift the real rounds into the wrong column: a missing ID is
+// left as a blank placeholder round, and a repeated one is logged and only
+// its first entry kept.
+func alignRounds(rounds []Round, maxRounds int, name string) []Round {
+	if len(rounds) > maxRounds {
+		warnf("%s has %d rounds on the leaderboard; only scoring rounds 1-%d", name, len(rounds), maxRounds)
+	}
+
+	byID := true
+	for _, r := range rounds {
+		if r.RoundID <= 0 {
+			byID = false
+			break
+		}
+	}
+	if !byID {
+		return rounds[:min(len(rounds), maxRounds)]
+	}
+
+	var aligned []Round
+	seen := map[int]bool{}
+	for _, r := range rounds {
+		switch {
+		case r.RoundID > maxRounds:
+			continue
+		case seen[r.RoundID]:
+			warnf("%s has round %d on the leaderboard more than once; scoring the first", name, r.RoundID)
+			continue
+		}
+		seen[r.RoundID] = true
+		for len(aligned) < r.RoundID {
+			aligned = append(aligned, Round{RoundID: len(aligned) + 1})
+		}
+		aligned[r.RoundID-1] = r
+	}
+	return aligned
+}
+
+// missedRoundScore is what each round a CUT, WD, or DQ player won't play
+// counts as under the penalty cut mode: the cut line plus opts.CutPenalty,
+// or 0 before a cut line is posted.
+func missedRoundScore(leaderboard *Leaderboard, opts ScoreOptions) int {
+	if len(leaderboard.CutLines) == 0 {
+		return 0
+	}
+	return parseCutScore(leaderboard.CutLines[0].CutScore) + opts.CutPenalty
+}
+
+// cutSize is how many players make the cut, with ties: the top 65.
+const cutSize = 65
+
+// projectCut returns the cut line expected after R2 while it's still to
+// come, or false once it's been made or if there's nothing to project from.
+// The feed's own projection in cutLines is used when there is one;
+// otherwise it's the total of the player in cutSize place.
+func projectCut(leaderboard *Leaderboard) (int, bool) {
+	var totals []int
+	for _, row := range leaderboard.LeaderboardRows {
+		switch {
+		case playerStatus(row.Position) == "CUT" || len(row.Rounds) > 2:
+			return 0, false
+		case playerStatus(row.Position) != "":
+			continue
+		}
+		if total, err := parseScore(row.Total); err == nil {
+			totals = append(totals, total)
+		}
+	}
+	if len(leaderboard.CutLines) > 0 && leaderboard.CutLines[0].CutScore != "" {
+		return parseCutScore(leaderboard.CutLines[0].CutScore), true
+	}
+	if len(totals) <= cutSize {
+		return 0, false
+	}
+	slices.Sort(totals)
+	return totals[cutSize-1], true
+}
+
+// parseCutScore converts a cut line such as "+4", "-1", or "E" into strokes
+// relative to par, keeping the sign. A missing cut line parses as 0.
+func parseCutScore(cut string) int {
+	return parseToPar(cut)
+}
+
+// loadConfig reads the pool config at filePath. A missing file is skipped
+// rather than an error, leaving the defaults: teams/ and leaderboard.json.
+// Members is whatever the file lists. buildTeams scores exactly those
+// members, and only when the list is empty does it discover every team
+// file in TeamsDir.
+func loadConfig(filePath string) (Config, error) {
+	cfg := Config{TeamsDir: "teams", Leaderboard: "leaderboard.json"}
+
+	file, err := os.Open(filePath)
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return Config{}, err
+	}
+	defer file.Close()
+
+	if err := json.NewDecoder(file).Decode(&cfg); err != nil {
+		return Config{}, fmt.Errorf("%s: %v", filePath, err)
+	}
+	if cfg.TeamsDir == "" {
+		cfg.TeamsDir = "teams"
+	}
+	if cfg.Leaderboard == "" {
+		cfg.Leaderboard = "leaderboard.json"
+	}
+	return cfg, nil
+}
+
+// discoverTeams returns the member name of every team file in dir, which is
+// the file name without its .json extension, sorted.
+func discoverTeams(dir string) ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no team files in %s", dir)
+	}
+	names := make([]string, len(paths))
+	for i, p := range paths {
+		names[i] = strings.TrimSuffix(filepath.Base(p), ".json")
+	}
+	return names, nil
+}
+
+func loadTeam(filePath string) (Team, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return Team{}, err
+	}
+	defer file.Close()
+
+	var team Team
+	if err := json.NewDecoder(file).Decode(&team); err != nil {
+		return Team{}, fmt.Errorf("%s: %v", filePath, err)
+	}
+	if err := validateTeam(team); err != nil {
+		return Team{}, fmt.Errorf("%s: %v", filePath, err)
+	}
+	return team, nil
+}
+
+// Scoreboard defaults for teams that don't pick their own color or emoji.
+const (
+	defaultTeamColor = "#fff"
+	defaultTeamEmoji = "🏌️"
+)
+
+// cssColor matches the colors a team file may set: hex or a named color.
+var cssColor = regexp.MustCompile(`^(#[0-9a-fA-F]{3}|#[0-9a-fA-F]{6}|[a-zA-Z]+)$`)
+
+// DisplayColor returns t's color for the scoreboard.
+func (t Team) DisplayColor() string {
+	if t.Color == "" {
+		return defaultTeamColor
+	}
+	return t.Color
+}
+
+// DisplayEmoji returns t's emoji for the scoreboard.
+func (t Team) DisplayEmoji() string {
+	if t.Emoji == "" {
+		return defaultTeamEmoji
+	}
+	return t.Emoji
+}
+
+// teamFileVersion is the newest team file format this build reads.
+const teamFileVersion = 1
+
+// validateTeam reports every data-entry problem in a loaded team file: an
+// unsupported version, a blank team name, no players, player names without
+// a first and last, or a color that isn't a hex or named CSS color.
+func validateTeam(team Team) error {
+	var problems []string
+	if team.Version < 0 || team.Version > teamFileVersion {
+		problems = append(problems, fmt.Sprintf("version %d isn't supported (expected 1 through %d); upgrade pga-tracker to read it", team.Version, teamFileVersion))
+	}
+	if strings.TrimSpace(team.TeamName) == "" {
+		problems = append(problems, "teamName is blank")
+	}
+	if len(team.Players) == 0 {
+		problems = append(problems, "players is empty")
+	}
+	if team.Color != "" && !cssColor.MatchString(team.Color) {
+		problems = append(problems, fmt.Sprintf("color %q isn't a hex or named CSS color", team.Color))
+	}
+	for i, name := range team.Players {
+		if first, last := splitName(name); first == "" || last == "" {
+			problems = append(problems, fmt.Sprintf("player %d (%q) needs a first and last name", i+1, name))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid team: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// validateTeamComposition checks team's roster against the draft rules,
+// reporting every tier with too many players. Players without a tier in the
+// team file aren't counted. It never affects scoring.
+func validateTeamComposition(team Team, rules CompositionRules) error {
+	byTier := map[int][]string{}
+	for _, name := range team.Players {
+		if tier, ok := team.Tiers[name]; ok {
+			byTier[tier] = append(byTier[tier], name)
+		}
+	}
+
+	var problems []string
+	for _, tier := range slices.Sorted(maps.Keys(rules.MaxPerTier)) {
+		if limit, names := rules.MaxPerTier[tier], byTier[tier]; len(names) > limit {
+			problems = append(problems, fmt.Sprintf("%d tier %d players (%s), max %d", len(names), tier, strings.Join(names, ", "), limit))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func fetchLeaderboard(opts FetchOptions) error {
+	if opts.TournID == "" && opts.SourceURL == "" {
+		return fmt.Errorf("tournament ID is required (set -tourn)")
+	}
+
+	url, validate := opts.SourceURL, func([]byte) error { return nil }
+	if url == "" {
+		if err := checkTournID(opts.TournID); err != nil {
+			return err
+		}
+		if err := checkYear(opts.Year); err != nil {
+			return err
+		}
+		url = fmt.Sprintf("%s/leaderboard?orgId=%s&tournId=%s&year=%s", apiBaseURL, opts.OrgID, opts.TournID, opts.Year)
+	} else {
+		validate = func(body []byte) error {
+			var leaderboard Leaderboard
+			if err := json.Unmarshal(body, &leaderboard); err != nil {
+				return fmt.Errorf("%s isn't a leaderboard: %v", opts.SourceURL, err)
+			}
+			return nil
+		}
+	}
+
+	fetched, err := fetchJSON(opts, url, validate)
+	if fetched {
+		recordFetch(err)
+	}
+	return err
+}
+
+// checkTournID rejects a -tourn value the API can't look up, so a typo fails
+// here rather than as a 400. IDs are numeric but kept as strings, since some,
+// like "026", are zero-padded and the API needs the zeros.
+func checkTournID(id string) error {
+	if id == "" || strings.Trim(id, "0123456789") != "" {
+		return fmt.Errorf("invalid -tourn %q: want a numeric tournament ID such as 026", id)
+	}
+	return nil
+}
+
+// checkYear rejects a -year value that isn't a four-digit year.
+func checkYear(year string) error {
+	if len(year) != 4 || strings.Trim(year, "0123456789") != "" {
+		return fmt.Errorf("invalid -year %q: want a four-digit year such as %s", year, tournYear)
+	}
+	return nil
+}
+
+// apiBaseURL is the RapidAPI live-golf-data endpoint every API request goes
+// to.
+var apiBaseURL = "https://live-golf-data.p.rapidapi.com"
+
+// fetchJSON downloads the JSON document at url to opts.OutPath, pretty-
+// printed, once validate accepts it. The cache rules of opts apply: a copy
+// younger than MaxAge is kept, a 304 keeps the saved copy, and Force skips
+// both. fetched reports whether a request was attempted, as opposed to
+// answered from the cache or held back by RatePerMinute.
+func fetchJSON(opts FetchOptions, url string, validate func([]byte) error) (fetched bool, err error) {
+	if !opts.Force && !shouldRefresh(opts.OutPath, opts.MaxAge) {
+		infof("Using cached %s (younger than %s)", opts.OutPath, opts.MaxAge)
+		return false, nil
+	}
+	if opts.RatePerMinute > 0 {
+		ok, err := allowFetch(rateLimitPath, opts.RatePerMinute, time.Now())
+		if err != nil {
+			return false, fmt.Errorf("rate limit: %v", err)
+		}
+		if !ok {
+			if _, err := os.Stat(opts.OutPath); err != nil {
+				return false, fmt.Errorf("rate limit of %d requests/minute reached and no cached %s", opts.RatePerMinute, opts.OutPath)
+			}
+			infof("Rate limit of %d requests/minute reached; keeping cached %s", opts.RatePerMinute, opts.OutPath)
+			return false, nil
+		}
+	}
+
+	req, err := apiRequest(opts, url)
+	if err != nil {
+		return true, err
+	}
+
+	// Only ask for a 304 when there's a cached copy to fall back on.
+	sidecar := validatorsPath(opts.OutPath)
+	if _, err := os.Stat(opts.OutPath); err == nil && !opts.Force {
+		loadValidators(sidecar).apply(req)
+	}
+
+	client := &http.Client{Timeout: opts.Timeout}
+	res, err := doWithRetry(client, req, opts.Retries)
+	if err != nil {
+		return true, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotModified {
+		infof("Not modified (304); keeping cached %s", opts.OutPath)
+		// Touch the cache so -max-age counts from this confirmation.
+		now := time.Now()
+		if err := os.Chtimes(opts.OutPath, now, now); err != nil {
+			warnf("Failed to touch %s: %v", opts.OutPath, err)
+		}
+		return true, nil
+	}
+	if res.StatusCode != http.StatusOK {
+		if msg := errorBody(res, req.Header.Get("x-rapidapi-key")); msg != "" {
+			return true, fmt.Errorf("unexpected status code: %d %s: %s", res.StatusCode, res.Status, msg)
+		}
+		return true, fmt.Errorf("unexpected status code: %d %s", res.StatusCode, res.Status)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return true, fmt.Errorf("Failed to read response body: %v", err)
+	}
+
+	// Optional: Pretty-print JSON to a file
+	var prettyJSON map[string]interface{}
+	if err := json.Unmarshal(body, &prettyJSON); err != nil {
+		return true, fmt.Errorf("Failed to parse JSON: %v", err)
+	}
+	if err := validate(body); err != nil {
+		return true, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(opts.OutPath), 0o755); err != nil {
+		return true, err
+	}
+	file, err := os.Create(opts.OutPath)
+	if err != nil {
+		return true, fmt.Errorf("Failed to create file: %v", err)
+	}
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ") // Pretty-print with indent
+	if err := encoder.Encode(prettyJSON); err != nil {
+		return true, fmt.Errorf("Failed to write JSON to file: %v", err)
+	}
+
+	if err := saveValidators(sidecar, res); err != nil {
+		warnf("Failed to save cache validators: %v", err)
+	}
+
+	debugf("Saved %s", opts.OutPath)
+	return true, nil
+}
+
+// maxErrorBody caps how much of an error response errorBody reports.
+const maxErrorBody = 300
+
+// errorBody returns the start of res's body for an error message, on one
+// line and cut to maxErrorBody bytes. Any echo of apiKey is redacted.
+func errorBody(res *http.Response, apiKey string) string {
+	data, _ := io.ReadAll(io.LimitReader(res.Body, maxErrorBody+1))
+	msg := strings.Join(strings.Fields(string(data)), " ")
+	if apiKey != "" {
+		msg = strings.ReplaceAll(msg, apiKey, "[redacted]")
+	}
+	if len(msg) > maxErrorBody {
+		msg = strings.ToValidUTF8(msg[:maxErrorBody], "") + "…"
+	}
+	return msg
+}
+
+// apiRequest builds the GET for url with opts.Headers. Unless the request
+// is for opts.SourceURL, it also carries the RapidAPI key.
+func apiRequest(opts FetchOptions, url string) (*http.Request, error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+
+	if opts.SourceURL == "" {
+		apiKey, err := loadAPIKey(opts.APIKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Add("x-rapidapi-key", apiKey)
+		req.Header.Add("x-rapidapi-host", "live-golf-data.p.rapidapi.com")
+	}
+	for key, values := range opts.Headers {
+		for _, v := range values {
+			req.Header.Add(key, v)
+		}
+	}
+	return req, nil
+}
+
+// loadAPIKey returns the RapidAPI key from keyFile when set, falling back to
+// the RAPID_GOLF_API_KEY environment variable.
+func loadAPIKey(keyFile string) (string, error) {
+	if keyFile != "" {
+		data, err := os.ReadFile(keyFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read API key file: %v", err)
+		}
+		if key := strings.TrimSpace(string(data)); key != "" {
+			return key, nil
+		}
+		warnf("API key file %s is empty; falling back to RAPID_GOLF_API_KEY", keyFile)
+	}
+	if key := strings.TrimSpace(os.Getenv("RAPID_GOLF_API_KEY")); key != "" {
+		return key, nil
+	}
+	return "", fmt.Errorf("no API key: set RAPID_GOLF_API_KEY or pass -api-key-file")
+}
+
+// shouldRefresh reports whether the file at path is missing or older than
+// maxAge. A maxAge of zero or less always refreshes.
+func shouldRefresh(path string, maxAge time.Duration) bool {
+	if maxAge <= 0 {
+		return true
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return true
+	}
+	return time.Since(info.ModTime()) > maxAge
+}
+
+// doWithRetry sends req up to attempts times, backing off exponentially
+// between tries. Only network errors, 429s, and 5xx responses are retried;
+// any other response, and the last attempt's 429 or 5xx, is returned to
+// the caller as-is so its body can explain the failure.
+func doWithRetry(client *http.Client, req *http.Request, attempts int) (*http.Response, error) {
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	backoff := time.Second
+	var lastErr error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		res, err := client.Do(req)
+		switch {
+		case err != nil:
+			lastErr = fmt.Errorf("failed to make request: %v", err)
+		case res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= 500:
+			if attempt == attempts {
+				return res, nil
+			}
+			res.Body.Close()
+			lastErr = fmt.Errorf("unexpected status code: %d %s", res.StatusCode, res.Status)
+		default:
+			return res, nil
+		}
+
+		if attempt < attempts {
+			warnf("Attempt %d/%d failed: %v; retrying in %s", attempt, attempts, lastErr, backoff)
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+	return nil, lastErr
+}
+
+// parseToPar converts a leaderboard score like "-5", "+3", or "E" into
+// strokes relative to par. Anything unparseable counts as even; use
+// parseScore where that needs to be detected.
+func parseToPar(s string) int {
+	toPar, _ := parseScore(s)
+	return toPar
+}
+
+// parseScore is parseToPar with an error for values that aren't a score,
+// such as the "-" the leaderboard shows before a player tees off.
+func parseScore(s string) (int, error) {
+	s = strings.TrimSpace(s)
+	if strings.EqualFold(s, "E") {
+		return 0, nil
+	}
+	toPar, err := strconv.Atoi(strings.TrimPrefix(s, "+"))
+	if err != nil {
+		return 0, fmt.Errorf("invalid score %q", s)
+	}
+	return toPar, nil
+}
+
+// parClass returns the CSS class for a score relative to par.
+func parClass(n int) string {
+	switch {
+	case n < 0:
+		return "under"
+	case n > 0:
+		return "over"
+	}
+	return "even"
+}
+
+// formatToPar renders a relative-to-par score the way leaderboards do:
+// "E" for even and an explicit "+" for over par.
+func formatToPar(n int) string {
+	switch {
+	case n == 0:
+		return "E"
+	case n > 0:
+		return fmt.Sprintf("+%d", n)
+	}
+	return strconv.Itoa(n)
+}
+
+// displayLocation is the time zone the scoreboard's timestamps are shown
+// in, set by -tz.
+var displayLocation = time.Local
+
+// newPageData assembles the template data for a scoreboard of teams. skipped
+// names the teams that couldn't be loaded, which the page notes.
+func newPageData(teams []Team, skipped []string, meta TournamentMeta, score ScoreOptions) PageData {
+	now := time.Now().In(displayLocation)
+	lastUpdated := now.Format(lastUpdatedLayout)
+	if noTimestamp {
+		lastUpdated = ""
+	}
+	return PageData{
+		Teams:          teams,
+		Skipped:        skipped,
+		LastUpdated:    lastUpdated,
+		CurrentYear:    now.Year(),
+		TournName:      meta.Name,
+		TournCourse:    meta.Course,
+		TournDates:     meta.Dates,
+		Tournament:     meta,
+		ShowProjection: score.Project,
+		ShowHoles:      hasHoleData(teams),
+		Unmatched:      unmatchedSummary(teams),
+		ShowNet:        hasHandicaps(teams),
+		RoundWeights:   weightsShown(score),
+		ProjectedCut:   teamsProjectedCut(teams),
+		RankRound:      score.RankRound,
+		SinceCut:       score.SinceCut,
+		LowRound:       lowRound(teams),
+		Cut:            cutLine(meta, score),
+	}
+}
+
+// countingPlayersOnly returns copies of teams listing only the players who
+// count toward each team's total, plus the Total row, for -hide-excluded.
+// The totals themselves are unchanged and teams isn't modified.
+func countingPlayersOnly(teams []Team) []Team {
+	shown := slices.Clone(teams)
+	for i := range shown {
+		shown[i].PlayerScores = slices.DeleteFunc(slices.Clone(shown[i].PlayerScores), func(p Player) bool {
+			return p.Excluded || p.Scratched
+		})
+	}
+	return shown
+}
+
+// teamsProjectedCut returns the projected cut getTeamScores gave teams'
+// players, or nil if none has one.
+func teamsProjectedCut(teams []Team) *int {
+	for _, t := range teams {
+		for _, p := range t.PlayerScores {
+			if p.ProjectedCut != nil {
+				return p.ProjectedCut
+			}
+		}
+	}
+	return nil
+}
+
+// weightsShown returns score's round weights for the scoreboard, or nil when
+// every round counts once and there's nothing to show.
+func weightsShown(score ScoreOptions) []int {
+	if !score.weighted() {
+		return nil
+	}
+	return score.RoundWeights
+}
+
+// hasHandicaps reports whether any team on the scoreboard plays handicaps.
+func hasHandicaps(teams []Team) bool {
+	for _, t := range teams {
+		if len(t.Handicaps) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// hasHoleData reports whether any player on teams has hole-by-hole scoring.
+func hasHoleData(teams []Team) bool {
+	for _, t := range teams {
+		for _, p := range t.PlayerScores {
+			if p.HolesPlayed > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// renderScoreboard writes the scoreboard page for data to outPath, creating
+// its parent directories as needed. A failed render leaves the previous page
+// intact, and with checkHTML set so does a page that fails checkPage.
+func renderScoreboard(data PageData, outPath string) error {
+	if !checkHTML {
+		return writePage(outPath, func(w io.Writer) error { return writeScoreboard(w, data) })
+	}
+	var buf bytes.Buffer
+	if err := writeScoreboard(&buf, data); err != nil {
+		return err
+	}
+	if err := checkPage(buf.Bytes()); err != nil {
+		return fmt.Errorf("not replacing %s: %v", outPath, err)
+	}
+	return writePage(outPath, func(w io.Writer) error {
+		_, err := buf.WriteTo(w)
+		return err
+	})
+}
+
+// checkHTML runs checkPage on every rendered scoreboard before it's
+// published, set by -check-html.
+var checkHTML bool
+
+// minPageSize is the smallest rendered page checkPage accepts; the built-in
+// templates' styles alone are larger.
+const minPageSize = 512
+
+// pageTags are the elements checkPage expects every opening tag of to be
+// closed, the ones a template edit is most likely to leave unbalanced.
+var pageTags = regexp.MustCompile(`<(/?)(html|head|body|table|tr|div|details)[\s>]`)
+
+// checkPage is a sanity check that page is a whole scoreboard: big enough,
+// a complete html document, and with its main elements balanced. It's not
+// a full HTML parser, just enough to catch a truncated or broken render.
+func checkPage(page []byte) error {
+	if len(bytes.TrimSpace(page)) < minPageSize {
+		return fmt.Errorf("rendered page is only %d bytes", len(page))
+	}
+	open := map[string]int{}
+	for _, m := range pageTags.FindAllSubmatch(page, -1) {
+		if len(m[1]) == 0 {
+			open[string(m[2])]++
+		} else {
+			open[string(m[2])]--
+		}
+	}
+	if open["html"] != 0 || open["body"] != 0 || !bytes.HasSuffix(bytes.TrimSpace(page), []byte("</html>")) {
+		return fmt.Errorf("rendered page isn't a complete html document")
+	}
+	var unbalanced []string
+	for _, tag := range slices.Sorted(maps.Keys(open)) {
+		if open[tag] != 0 {
+			unbalanced = append(unbalanced, fmt.Sprintf("<%s> (%+d)", tag, open[tag]))
+		}
+	}
+	if len(unbalanced) > 0 {
+		return fmt.Errorf("rendered page has unclosed or stray tags: %s", strings.Join(unbalanced, ", "))
+	}
+	return nil
+}
+
+// writePage renders a page with write to a temporary file beside outPath and
+// renames it into place, so readers never see a partly written page.
+func writePage(outPath string, write func(io.Writer) error) error {
+	dir := filepath.Dir(outPath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(outPath)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := write(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	// CreateTemp makes the file owner-only; published pages should be
+	// readable like any other file os.Create would have made.
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), outPath)
+}
+
+//go:embed templates/*.html
+var embeddedTemplates embed.FS
+
+// lastUpdatedLayout is how pages and notifications show when standings were
+// scored.
+const lastUpdatedLayout = "Jan 2, 2006 3:04PM MST"
+
+// noTimestamp leaves the "last updated" time off rendered pages, so the
+// same data always renders byte-identical HTML.
+var noTimestamp bool
+
+// templatePath is a scoreboard template on disk to use instead of the
+// embedded one, set by -template.
+var templatePath string
+
+// Scoreboard layouts for PageData.Layout.
+const (
+	layoutTable   = "table"
+	layoutCompact = "compact"
+)
+
+// layoutTemplate picks the template that renders layout: "compact" for the
+// stacked mobile cards, and "scoreboard" for the full table.
+func layoutTemplate(layout string) string {
+	if layout == layoutCompact {
+		return "compact"
+	}
+	return "scoreboard"
+}
+
+// writeScoreboard executes the scoreboard template for data into w. The
+// templates are the embedded defaults unless templatePath is set. A custom
+// template may define "scoreboard" and "compact" or be the page itself; a
+// layout it doesn't define falls back to "scoreboard".
+func writeScoreboard(w io.Writer, data PageData) error {
+	name, parse := "scoreboard.html", func(t *template.Template) (*template.Template, error) {
+		return t.ParseFS(embeddedTemplates, "templates/*.html")
+	}
+	if templatePath != "" {
+		name, parse = filepath.Base(templatePath), func(t *template.Template) (*template.Template, error) {
+			return t.ParseFiles(templatePath)
+		}
+	}
+
+	tmpl, err := parse(template.New(name).Funcs(template.FuncMap{
+		"isTotal": func(name string) bool {
+			return name == "Total"
+		},
+		"toPar":     formatToPar,
+		"parClass":  parClass,
+		"join":      strings.Join,
+		"teamTotal": teamGrandTotal,
+	}))
+	if err != nil {
+		return err
+	}
+
+	if page := tmpl.Lookup(layoutTemplate(data.Layout)); page != nil {
+		return page.Execute(w, data)
+	}
+	if page := tmpl.Lookup("scoreboard"); page != nil {
+		warnf("Template has no %s layout; rendering the table", data.Layout)
+		return page.Execute(w, data)
+	}
+	return tmpl.Execute(w, data)
 }
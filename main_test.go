@@ -0,0 +1,1526 @@
+package main
+
+import (
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// mustLoadLeaderboard loads the leaderboard at path, failing t if it can't.
+func mustLoadLeaderboard(t *testing.T, path string) *Leaderboard {
+	t.Helper()
+	leaderboard, err := loadLeaderboard(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return leaderboard
+}
+
+func TestGetTeamScores(t *testing.T) {
+	type row struct {
+		name     string
+		total    int
+		excluded bool
+	}
+
+	tests := []struct {
+		name      string
+		players   []string
+		scratched []string
+		count     int
+		want      []row
+		wantTotal Player
+	}{
+		{
+			name:    "top four count",
+			players: []string{"Adam One", "Ben Two", "Carl Three", "Dan Four", "Frank Six"},
+			count:   4,
+			want: []row{
+				{"Frank Six", -8, false},
+				{"Adam One", -6, false},
+				{"Ben Two", -4, false},
+				{"Carl Three", 2, false},
+				{"Dan Four", 3, true},
+			},
+			wantTotal: Player{R1: -6, R2: -5, R3: -3, R4: -2, Total: -16},
+		},
+		{
+			name:    "cut player is penalized",
+			players: []string{"Adam One", "Ben Two", "Eric Five"},
+			count:   4,
+			want: []row{
+				{"Adam One", -6, false},
+				{"Ben Two", -4, false},
+				{"Eric Five", 15, false},
+			},
+			wantTotal: Player{R1: -2, R2: 0, R3: 3, R4: 4, Total: 5},
+		},
+		{
+			name:      "scratched player never counts",
+			players:   []string{"Adam One", "Ben Two", "Carl Three", "Dan Four", "Frank Six"},
+			scratched: []string{"Frank Six"},
+			count:     4,
+			want: []row{
+				{"Adam One", -6, false},
+				{"Ben Two", -4, false},
+				{"Carl Three", 2, false},
+				{"Dan Four", 3, false},
+				{"Frank Six", -8, false},
+			},
+			wantTotal: Player{R1: -3, R2: -2, R3: 0, R4: 0, Total: -5},
+		},
+		{
+			name:    "missing player is skipped",
+			players: []string{"Adam One", "Nobody Here", "Ben Two"},
+			count:   4,
+			want: []row{
+				{"Adam One", -6, false},
+				{"Ben Two", -4, false},
+			},
+			wantTotal: Player{R1: -4, R2: -3, R3: -2, R4: -1, Total: -10},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			team := getTeamScores(mustLoadLeaderboard(t, "testdata/leaderboard.json"), Team{Players: tt.players, Excluded: tt.scratched}, ScoreOptions{Count: tt.count, CutPenalty: 3})
+			if len(team) != len(tt.want)+1 {
+				t.Fatalf("got %d rows, want %d players plus Total", len(team), len(tt.want))
+			}
+
+			for i, w := range tt.want {
+				p := team[i]
+				if p.FullName != w.name || p.Total != w.total || p.Excluded != w.excluded {
+					t.Errorf("row %d = {%s %d %v}, want {%s %d %v}", i, p.FullName, p.Total, p.Excluded, w.name, w.total, w.excluded)
+				}
+			}
+
+			total := team[len(team)-1]
+			if total.FullName != "Total" {
+				t.Fatalf("last row = %q, want Total", total.FullName)
+			}
+			got := [5]int{total.R1, total.R2, total.R3, total.R4, total.Total}
+			want := [5]int{tt.wantTotal.R1, tt.wantTotal.R2, tt.wantTotal.R3, tt.wantTotal.R4, tt.wantTotal.Total}
+			if got != want {
+				t.Errorf("Total row = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestGetTeamScoresInProgress(t *testing.T) {
+	team := getTeamScores(mustLoadLeaderboard(t, "testdata/leaderboard.json"), Team{Players: []string{"Gary Seven", "Hal Eight"}}, ScoreOptions{Count: 4, CutPenalty: 3})
+
+	gary, hal := team[0], team[1]
+	if !gary.InProgress || gary.Thru != "12" || gary.Today != -2 || gary.Total != -3 {
+		t.Errorf("Gary Seven = %+v, want in progress thru 12 at -2, total -3", gary)
+	}
+	if got := gary.Round(2); got != "thru 12 (-2)" {
+		t.Errorf("Gary Seven Round(2) = %q, want %q", got, "thru 12 (-2)")
+	}
+	if hal.InProgress || hal.RoundsScored != 1 || hal.Total != 1 {
+		t.Errorf("Hal Eight = %+v, want one scored round and total 1", hal)
+	}
+}
+
+func TestGetTeamScoresCumulative(t *testing.T) {
+	team := getTeamScores(mustLoadLeaderboard(t, "testdata/leaderboard.json"), Team{Players: []string{"Adam One", "Ben Two"}}, ScoreOptions{Count: 4, CutPenalty: 3})
+
+	total := team[len(team)-1]
+	if want := [4]int{-4, -7, -9, -10}; total.Cumulative != want {
+		t.Errorf("Total cumulative = %v, want %v", total.Cumulative, want)
+	}
+	if got := total.AfterRound(2); got != "-7" {
+		t.Errorf("AfterRound(2) = %q, want %q", got, "-7")
+	}
+}
+
+func TestLoadTeamValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		wantErr string
+	}{
+		{"valid", `{"teamName": "Team A", "players": ["Adam One", "Min Woo Lee"]}`, ""},
+		{"blank name", `{"teamName": " ", "players": ["Adam One"]}`, "teamName is blank"},
+		{"no players", `{"teamName": "Team A", "players": []}`, "players is empty"},
+		{"one-word player", `{"teamName": "Team A", "players": ["Adam"]}`, `player 1 ("Adam") needs a first and last name`},
+		{"current version", `{"version": 1, "teamName": "Team A", "players": ["Adam One"]}`, ""},
+		{"hex color", `{"teamName": "Team A", "players": ["Adam One"], "color": "#1e90ff", "emoji": "🦅"}`, ""},
+		{"bad color", `{"teamName": "Team A", "players": ["Adam One"], "color": "red; background: url(x)"}`, "isn't a hex or named CSS color"},
+		{"future version", `{"version": 2, "teamName": "Team A", "players": ["Adam One"]}`, "version 2 isn't supported (expected 1 through 1)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "team.json")
+			if err := os.WriteFile(path, []byte(tt.json), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := loadTeam(path)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("loadTeam: %v", err)
+			case tt.wantErr != "" && err == nil:
+				t.Errorf("loadTeam succeeded, want error containing %q", tt.wantErr)
+			case err != nil && (!strings.Contains(err.Error(), tt.wantErr) || !strings.Contains(err.Error(), path)):
+				t.Errorf("loadTeam error = %q, want path and %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseCutScore(t *testing.T) {
+	tests := []struct {
+		cut  string
+		want int
+	}{
+		{"+4", 4},
+		{"-1", -1},
+		{"E", 0},
+		{"", 0},
+	}
+	for _, tt := range tests {
+		if got := parseCutScore(tt.cut); got != tt.want {
+			t.Errorf("parseCutScore(%q) = %d, want %d", tt.cut, got, tt.want)
+		}
+	}
+}
+
+func TestProjectTotal(t *testing.T) {
+	tests := []struct {
+		name     string
+		player   Player
+		fieldAvg float64
+		want     int
+	}{
+		{"one round played", Player{Total: -3, RoundsScored: 1}, 0.4, -2},
+		{"finished", Player{Total: -8, RoundsScored: 4}, 1.5, -8},
+		{"not started", Player{}, -0.5, -2},
+	}
+	for _, tt := range tests {
+		if got := projectTotal(tt.player, tt.fieldAvg, 4); got != tt.want {
+			t.Errorf("%s: projectTotal = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCompareTeams(t *testing.T) {
+	team := func(name string, rounds [4]int, counted ...[4]int) Team {
+		tm := Team{TeamName: name}
+		for _, r := range counted {
+			tm.PlayerScores = append(tm.PlayerScores, Player{FullName: "P", R1: r[0], R2: r[1], R3: r[2], R4: r[3], RoundsScored: 4})
+		}
+		total := Player{FullName: "Total", R1: rounds[0], R2: rounds[1], R3: rounds[2], R4: rounds[3], RoundsScored: 4}
+		total.Total = rounds[0] + rounds[1] + rounds[2] + rounds[3]
+		tm.PlayerScores = append(tm.PlayerScores, total)
+		return tm
+	}
+
+	tests := []struct {
+		name string
+		a, b Team
+		want int
+	}{
+		{
+			name: "lower total wins",
+			a:    team("Zed", [4]int{-2, 0, 0, 0}, [4]int{-2, 0, 0, 0}),
+			b:    team("Alpha", [4]int{-1, 0, 0, 0}, [4]int{-1, 0, 0, 0}),
+			want: -1,
+		},
+		{
+			name: "lowest single round breaks a tie",
+			a:    team("Zed", [4]int{-2, 0, 0, 0}, [4]int{-5, 3, 0, 0}),
+			b:    team("Alpha", [4]int{-2, 0, 0, 0}, [4]int{-1, -1, 0, 0}),
+			want: -1,
+		},
+		{
+			name: "final round breaks a tie",
+			a:    team("Zed", [4]int{0, 0, 0, -2}, [4]int{0, 0, 2, -2}),
+			b:    team("Alpha", [4]int{0, 0, -2, 0}, [4]int{0, 2, -2, 0}),
+			want: -1,
+		},
+		{
+			name: "name breaks a full tie",
+			a:    team("Zed", [4]int{-1, 0, 0, 0}, [4]int{-1, 0, 0, 0}),
+			b:    team("Alpha", [4]int{-1, 0, 0, 0}, [4]int{-1, 0, 0, 0}),
+			want: 1,
+		},
+	}
+	for _, tt := range tests {
+		if got := compareTeams(tt.a, tt.b); got != tt.want {
+			t.Errorf("%s: compareTeams = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFetchLeaderboardParams(t *testing.T) {
+	var gotQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.RawQuery
+		w.Write([]byte(`{"leaderboardRows": []}`))
+	}))
+	defer srv.Close()
+	prev := apiBaseURL
+	apiBaseURL = srv.URL
+	defer func() { apiBaseURL = prev }()
+	t.Setenv("RAPID_GOLF_API_KEY", "secret-key")
+
+	tests := []struct {
+		tourn, year string
+		wantErr     string
+	}{
+		{"026", "2026", ""},
+		{"", "2026", "tournament ID is required"},
+		{"26a", "2026", `invalid -tourn "26a"`},
+		{"026", "26", `invalid -year "26"`},
+		{"026", "20x6", `invalid -year "20x6"`},
+	}
+	for _, tt := range tests {
+		gotQuery = ""
+		opts := FetchOptions{OrgID: "1", TournID: tt.tourn, Year: tt.year, OutPath: filepath.Join(t.TempDir(), "leaderboard.json"), Timeout: time.Second, Retries: 1}
+		err := fetchLeaderboard(opts)
+		switch {
+		case tt.wantErr == "" && err != nil:
+			t.Errorf("-tourn %q -year %q: %v", tt.tourn, tt.year, err)
+		case tt.wantErr == "" && !strings.Contains(gotQuery, "tournId=026&"):
+			t.Errorf("query = %s, want the zero-padded tournId kept", gotQuery)
+		case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+			t.Errorf("-tourn %q -year %q: error = %v, want %q", tt.tourn, tt.year, err, tt.wantErr)
+		case tt.wantErr != "" && gotQuery != "":
+			t.Errorf("-tourn %q -year %q: request sent despite the error", tt.tourn, tt.year)
+		}
+	}
+}
+
+func TestFetchLeaderboardQuotaError(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"message": "You have exceeded the MONTHLY quota for key secret-key"}`))
+	}))
+	defer srv.Close()
+	prev := apiBaseURL
+	apiBaseURL = srv.URL
+	defer func() { apiBaseURL = prev }()
+	t.Setenv("RAPID_GOLF_API_KEY", "secret-key")
+
+	opts := FetchOptions{OrgID: "1", TournID: "026", Year: "2026", OutPath: filepath.Join(t.TempDir(), "leaderboard.json"), Timeout: time.Second, Retries: 1}
+	err := fetchLeaderboard(opts)
+	if err == nil || !strings.Contains(err.Error(), "exceeded the MONTHLY quota for key [redacted]") {
+		t.Errorf("error = %v, want the redacted quota message", err)
+	}
+	if requests != 1 {
+		t.Errorf("%d requests, want 1", requests)
+	}
+}
+
+func TestFetchLeaderboardSourceURL(t *testing.T) {
+	var gotAuth, gotKey string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth, gotKey = r.Header.Get("Authorization"), r.Header.Get("x-rapidapi-key")
+		w.Write([]byte(`{"name": "Mock Open", "leaderboardRows": [{"firstName": "Adam", "lastName": "One", "position": "1"}]}`))
+	}))
+	defer srv.Close()
+
+	var headers headerFlags
+	if err := headers.Set("Authorization=Bearer secret"); err != nil {
+		t.Fatal(err)
+	}
+	if err := headers.Set("no-equals"); err == nil {
+		t.Error("Set(\"no-equals\") succeeded, want an error")
+	}
+
+	out := filepath.Join(t.TempDir(), "leaderboard.json")
+	opts := FetchOptions{SourceURL: srv.URL, Headers: headers.header(), OutPath: out, Timeout: time.Second, Retries: 1}
+	if err := fetchLeaderboard(opts); err != nil {
+		t.Fatalf("fetchLeaderboard: %v", err)
+	}
+	if gotAuth != "Bearer secret" || gotKey != "" {
+		t.Errorf("request headers = Authorization %q, x-rapidapi-key %q; want the -header value and no API key", gotAuth, gotKey)
+	}
+	team := getTeamScores(mustLoadLeaderboard(t, out), Team{Players: []string{"Adam One"}}, ScoreOptions{Count: 1})
+	if len(team) != 2 {
+		t.Errorf("getTeamScores on the fetched file = %v; want Adam One and a Total", team)
+	}
+}
+
+func TestFetchLeaderboardForce(t *testing.T) {
+	requests, conditional := 0, 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			conditional++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"leaderboardRows": []}`))
+	}))
+	defer srv.Close()
+
+	out := filepath.Join(t.TempDir(), "leaderboard.json")
+	opts := FetchOptions{SourceURL: srv.URL, OutPath: out, Timeout: time.Second, Retries: 1, MaxAge: time.Hour}
+	for range 2 {
+		if err := fetchLeaderboard(opts); err != nil {
+			t.Fatalf("fetchLeaderboard: %v", err)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("%d requests within -max-age, want 1", requests)
+	}
+
+	opts.Force = true
+	if err := fetchLeaderboard(opts); err != nil {
+		t.Fatalf("fetchLeaderboard with Force: %v", err)
+	}
+	if requests != 2 || conditional != 0 {
+		t.Errorf("with Force: %d requests, %d conditional; want an unconditional second request", requests, conditional)
+	}
+
+	opts.Force, opts.MaxAge = false, 0
+	if err := fetchLeaderboard(opts); err != nil {
+		t.Fatalf("fetchLeaderboard: %v", err)
+	}
+	if conditional != 1 {
+		t.Errorf("%d conditional requests after the cache expired, want 1", conditional)
+	}
+}
+
+func TestFetchLeaderboardErrorBody(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"message": "You have exceeded the MONTHLY quota for key ` + r.Header.Get("x-rapidapi-key") + `"}` + strings.Repeat(" padding", 100)))
+	}))
+	defer srv.Close()
+
+	prev := apiBaseURL
+	apiBaseURL = srv.URL
+	defer func() { apiBaseURL = prev }()
+	t.Setenv("RAPID_GOLF_API_KEY", "secret-key")
+
+	opts := FetchOptions{TournID: "525", Year: "2026", OutPath: filepath.Join(t.TempDir(), "leaderboard.json"), Timeout: time.Second, Retries: 1}
+	err := fetchLeaderboard(opts)
+	if err == nil {
+		t.Fatal("fetchLeaderboard succeeded on a 403")
+	}
+	msg := err.Error()
+	if !strings.Contains(msg, "403") || !strings.Contains(msg, "exceeded the MONTHLY quota") {
+		t.Errorf("error = %q, want the status and the API's message", msg)
+	}
+	if strings.Contains(msg, "secret-key") {
+		t.Errorf("error = %q leaks the API key", msg)
+	}
+	if !strings.HasSuffix(msg, "…") {
+		t.Errorf("error = %q, want the long body truncated", msg)
+	}
+}
+
+func TestLoadAPIKey(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "key")
+	if err := os.WriteFile(keyFile, []byte("  from-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("RAPID_GOLF_API_KEY", "from-env")
+	if got, err := loadAPIKey(keyFile); err != nil || got != "from-file" {
+		t.Errorf("loadAPIKey(file) = %q, %v; want from-file", got, err)
+	}
+	if got, err := loadAPIKey(""); err != nil || got != "from-env" {
+		t.Errorf("loadAPIKey(\"\") = %q, %v; want from-env", got, err)
+	}
+
+	t.Setenv("RAPID_GOLF_API_KEY", "")
+	if _, err := loadAPIKey(""); err == nil {
+		t.Error("loadAPIKey with no key succeeded, want error")
+	}
+}
+
+func TestDuplicatePicks(t *testing.T) {
+	teams := []Team{
+		{TeamName: "Team A", Players: []string{"Tom Kim", "Adam One"}},
+		{TeamName: "Team B", Players: []string{"Ben Two", "tom kim"}},
+		{TeamName: "Team C", Players: []string{"Carl Three"}},
+	}
+
+	got := duplicatePicks(teams)
+	want := []string{"Tom Kim (Team A, Team B)"}
+	if !slices.Equal(got, want) {
+		t.Errorf("duplicatePicks = %q, want %q", got, want)
+	}
+}
+
+func TestParseScore(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int
+		wantErr bool
+	}{
+		{"E", 0, false},
+		{"-5", -5, false},
+		{"+3", 3, false},
+		{" 2 ", 2, false},
+		{"-", 0, true},
+		{"", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parseScore(tt.in)
+		if got != tt.want || (err != nil) != tt.wantErr {
+			t.Errorf("parseScore(%q) = %d, %v; want %d, error %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestGetTeamScoresSelectedRounds(t *testing.T) {
+	opts := ScoreOptions{Count: 4, CutPenalty: 3, Rounds: []int{3, 4}}
+	team := getTeamScores(mustLoadLeaderboard(t, "testdata/leaderboard.json"), Team{Players: []string{"Adam One", "Eric Five"}}, opts)
+
+	// Adam One shot -1 and E on the weekend; Eric Five missed the cut and
+	// takes the +5 penalty in both weekend rounds.
+	if team[0].FullName != "Adam One" || team[0].Total != -1 || team[0].R1 != -3 {
+		t.Errorf("Adam One = %+v, want weekend total -1 with R1 still -3", team[0])
+	}
+	if team[1].Total != 10 {
+		t.Errorf("Eric Five total = %d, want 10", team[1].Total)
+	}
+	if total := team[len(team)-1]; total.Total != 9 {
+		t.Errorf("team total = %d, want 9", total.Total)
+	}
+}
+
+func TestGetTeamScoresWhatIf(t *testing.T) {
+	opts := ScoreOptions{Count: 4, CutPenalty: 3, WhatIf: map[string]int{"adam one": -5, "Eric Five": -10}}
+	team := getTeamScores(mustLoadLeaderboard(t, "testdata/leaderboard.json"), Team{Players: []string{"Adam One", "Eric Five"}}, opts)
+
+	// Adam One's E in R4 becomes -5; Eric Five missed the cut, so his
+	// simulated round is ignored.
+	if team[0].FullName != "Adam One" || team[0].R4 != -5 || team[0].Total != -11 {
+		t.Errorf("Adam One = %+v, want R4 -5 and total -11", team[0])
+	}
+	if team[1].Total != 15 {
+		t.Errorf("Eric Five total = %d, want 15", team[1].Total)
+	}
+}
+
+func TestGetTeamScoresCutMode(t *testing.T) {
+	tests := []struct {
+		mode      string
+		total     int
+		scored    int
+		scratched bool
+	}{
+		{cutModePenalty, 15, 4, false},
+		{cutModeZero, 5, 4, false},
+		{cutModeDrop, 5, 2, true},
+	}
+	for _, tt := range tests {
+		opts := ScoreOptions{Count: 4, CutPenalty: 3, CutMode: tt.mode}
+		team := getTeamScores(mustLoadLeaderboard(t, "testdata/leaderboard.json"), Team{Players: []string{"Eric Five"}}, opts)
+		eric := team[0]
+		if eric.Total != tt.total || eric.RoundsScored != tt.scored || eric.Scratched != tt.scratched {
+			t.Errorf("%s: Eric Five = total %d, %d rounds, scratched %v; want %d, %d, %v",
+				tt.mode, eric.Total, eric.RoundsScored, eric.Scratched, tt.total, tt.scored, tt.scratched)
+		}
+	}
+}
+
+func TestGetTeamScoresOverrides(t *testing.T) {
+	opts := ScoreOptions{Count: 4, CutPenalty: 3, Overrides: []ScoreOverride{
+		{Player: "Adam One", Round: 2, Score: -5, Note: "scorecard error"},
+		{Player: "Ben Two", Round: 4, Score: 0},
+	}}
+	team := getTeamScores(mustLoadLeaderboard(t, "testdata/leaderboard.json"), Team{Players: []string{"Adam One", "Ben Two"}}, opts)
+
+	// Adam One's R2 goes from -2 to -5; Ben Two's R4 from -1 to E.
+	if team[0].FullName != "Adam One" || team[0].R2 != -5 || team[0].Total != -9 || team[0].BestRound != -5 {
+		t.Errorf("Adam One = %+v, want R2 -5 and total -9", team[0])
+	}
+	if team[1].R4 != 0 || team[1].Total != -3 {
+		t.Errorf("Ben Two = %+v, want R4 E and total -3", team[1])
+	}
+}
+
+func TestGetTeamScoresHandicaps(t *testing.T) {
+	// Ben Two (-4 gross) gets 3 strokes and passes Adam One (-6 gross).
+	names := []string{"Adam One", "Ben Two", "Carl Three"}
+	team := getTeamScores(mustLoadLeaderboard(t, "testdata/leaderboard.json"), Team{Players: names, Handicaps: map[string]int{"Ben Two": 3}}, ScoreOptions{Count: 2, CutPenalty: 3})
+
+	if team[0].FullName != "Ben Two" || team[0].Gross != -4 || team[0].Total != -7 {
+		t.Errorf("team[0] = %+v, want Ben Two -4 gross, -7 net", team[0])
+	}
+	total := team[len(team)-1]
+	if total.Gross != -10 || total.Handicap != 3 || total.Total != -13 {
+		t.Errorf("Total row = gross %d, hcp %d, net %d; want -10, 3, -13", total.Gross, total.Handicap, total.Total)
+	}
+	if got := netTotal(Player{Gross: 5}, 2); got != 3 {
+		t.Errorf("netTotal = %d, want 3", got)
+	}
+}
+
+func TestParseRounds(t *testing.T) {
+	if got, err := parseRounds("3, 4"); err != nil || !slices.Equal(got, []int{3, 4}) {
+		t.Errorf("parseRounds(\"3, 4\") = %v, %v", got, err)
+	}
+	if got, err := parseRounds(""); err != nil || got != nil {
+		t.Errorf("parseRounds(\"\") = %v, %v; want all rounds", got, err)
+	}
+	for _, bad := range []string{"0", "5", "3,x"} {
+		if _, err := parseRounds(bad); err == nil {
+			t.Errorf("parseRounds(%q) succeeded, want error", bad)
+		}
+	}
+}
+
+func TestParClass(t *testing.T) {
+	for n, want := range map[int]string{-3: "under", 0: "even", 2: "over"} {
+		if got := parClass(n); got != want {
+			t.Errorf("parClass(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
+
+func TestSetBehindLeader(t *testing.T) {
+	teams := []Team{
+		{TeamName: "Team A", PlayerScores: []Player{{FullName: "Total", Total: -12}}},
+		{TeamName: "Team B", PlayerScores: []Player{{FullName: "Total", Total: -9}}},
+		{TeamName: "Team C", PlayerScores: []Player{{FullName: "Total", Total: -12}}},
+	}
+	setBehindLeader(teams)
+
+	for i, want := range []int{0, 3, 0} {
+		if got := teams[i].BehindLeader; got != want {
+			t.Errorf("%s BehindLeader = %d, want %d", teams[i].TeamName, got, want)
+		}
+	}
+}
+
+func TestRankTeamsByRound(t *testing.T) {
+	teams := []Team{
+		{TeamName: "Team A", PlayerScores: []Player{{FullName: "Total", R1: -6, R2: 1, Total: -5}}},
+		{TeamName: "Team B", PlayerScores: []Player{{FullName: "Total", R1: 0, R2: -3, Total: -3}}},
+		{TeamName: "Team C", PlayerScores: []Player{{FullName: "Total", R1: -1, R2: -1, Total: -2}}},
+	}
+	rankTeams(teams, ScoreOptions{RankRound: 2})
+
+	for i, want := range []struct {
+		name   string
+		behind int
+	}{{"Team B", 0}, {"Team C", 2}, {"Team A", 4}} {
+		if teams[i].TeamName != want.name || teams[i].BehindLeader != want.behind {
+			t.Errorf("teams[%d] = %s %d back, want %s %d back", i, teams[i].TeamName, teams[i].BehindLeader, want.name, want.behind)
+		}
+	}
+}
+
+func TestRankTeamsSinceCut(t *testing.T) {
+	teams := []Team{
+		// Team A's cut player is carrying penalty rounds that don't count
+		// on the weekend.
+		{TeamName: "Team A", PlayerScores: []Player{
+			{FullName: "Adam One", R1: -5, R2: -4, R3: 1, R4: 0},
+			{FullName: "Eric Five", Status: "CUT", R3: 5, R4: 5},
+			{FullName: "Total", Total: 2},
+		}},
+		{TeamName: "Team B", PlayerScores: []Player{
+			{FullName: "Ben Two", R1: 2, R2: 1, R3: -3, R4: -2},
+			{FullName: "Carl Three", R3: -1, R4: 4, Excluded: true},
+			{FullName: "Total", Total: -2},
+		}},
+	}
+	rankTeams(teams, ScoreOptions{SinceCut: true})
+
+	for i, want := range []struct {
+		name            string
+		weekend, behind int
+	}{{"Team B", -5, 0}, {"Team A", 1, 6}} {
+		if teams[i].TeamName != want.name || teams[i].WeekendTotal() != want.weekend || teams[i].BehindLeader != want.behind {
+			t.Errorf("teams[%d] = %s %d on the weekend, %d back; want %s %d, %d back", i, teams[i].TeamName, teams[i].WeekendTotal(), teams[i].BehindLeader, want.name, want.weekend, want.behind)
+		}
+	}
+}
+
+func TestCutLine(t *testing.T) {
+	meta := TournamentMeta{CutScore: "+3"}
+	tests := []struct {
+		score ScoreOptions
+		want  CutLine
+	}{
+		{ScoreOptions{CutPenalty: 3}, CutLine{Score: 3, MissedRound: 6, Mode: cutModePenalty}},
+		{ScoreOptions{CutPenalty: 3, CutMode: cutModeZero}, CutLine{Score: 3, Mode: cutModeZero}},
+		{ScoreOptions{CutPenalty: 3, CutMode: cutModeDrop}, CutLine{Score: 3, Mode: cutModeDrop}},
+	}
+	for _, tt := range tests {
+		if got := cutLine(meta, tt.score); got == nil || *got != tt.want {
+			t.Errorf("cutLine(%+v) = %+v, want %+v", tt.score, got, tt.want)
+		}
+	}
+	if got := cutLine(TournamentMeta{}, ScoreOptions{}); got != nil {
+		t.Errorf("cutLine with no cut posted = %+v, want nil", got)
+	}
+}
+
+func TestSetLastPlace(t *testing.T) {
+	team := func(name string, total int) Team {
+		return Team{TeamName: name, PlayerScores: []Player{{FullName: "Total", Total: total}}}
+	}
+	tests := []struct {
+		name  string
+		teams []Team
+		label string
+		want  []string
+	}{
+		{"alone", []Team{team("A", -12), team("B", -9), team("C", -3)}, "", []string{"", "", defaultLastPlaceLabel}},
+		{"tied", []Team{team("A", -12), team("B", -3), team("C", -3)}, "Buys drinks", []string{"", "Buys drinks", "Buys drinks"}},
+		{"everyone tied", []Team{team("A", -3), team("B", -3)}, "", []string{"", ""}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setLastPlace(tt.teams, tt.label, nil)
+			for i, want := range tt.want {
+				if got := tt.teams[i].LastPlace; got != want {
+					t.Errorf("%s LastPlace = %q, want %q", tt.teams[i].TeamName, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestSetLastPlaceRankRound(t *testing.T) {
+	// A leads R3 but is last overall; in the R3 view B is last.
+	teams := []Team{
+		{TeamName: "A", PlayerScores: []Player{{FullName: "Total", R3: -5, Total: 2}}},
+		{TeamName: "B", PlayerScores: []Player{{FullName: "Total", R3: 1, Total: -8}}},
+	}
+	opts := ScoreOptions{RankRound: 3}
+	rankTeams(teams, opts)
+	setLastPlace(teams, "", opts.viewTotal())
+	if teams[0].TeamName != "A" || teams[0].BehindLeader != 0 || teams[0].LastPlace != "" {
+		t.Errorf("R3 leader %s is %d back with LastPlace %q; want A, 0, none", teams[0].TeamName, teams[0].BehindLeader, teams[0].LastPlace)
+	}
+	if teams[1].LastPlace != defaultLastPlaceLabel {
+		t.Errorf("%s LastPlace = %q, want %q", teams[1].TeamName, teams[1].LastPlace, defaultLastPlaceLabel)
+	}
+}
+
+func TestBuildTeamsSkipsMissing(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "A.json"), []byte(`{"teamName": "Team A", "players": ["Adam One", "Ben Two", "Carl Three", "Dan Four"]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := Config{
+		Members:     []string{"A", "JR"},
+		TeamsDir:    dir,
+		Leaderboard: "testdata/leaderboard.json",
+	}
+	teams, skipped, err := buildTeams(cfg, ScoreOptions{Count: 4, CutPenalty: 3})
+	if err != nil {
+		t.Fatalf("buildTeams: %v", err)
+	}
+	if len(teams) != 1 || teams[0].TeamName != "Team A" {
+		t.Errorf("teams = %v, want just Team A", teams)
+	}
+	if !slices.Equal(skipped, []string{"JR"}) {
+		t.Errorf("skipped = %v, want [JR]", skipped)
+	}
+}
+
+func TestTallyHoles(t *testing.T) {
+	rounds := []Round{
+		{Holes: []Hole{
+			{HoleID: 1, Par: 4, Strokes: 3},
+			{HoleID: 2, Par: 5, Strokes: 3},
+			{HoleID: 3, Par: 3, Strokes: 5},
+			{HoleID: 4, Par: 4, Strokes: 4},
+		}},
+		{Holes: []Hole{
+			{HoleID: 1, Par: 4, Strokes: 3},
+			{HoleID: 2, Par: 5, Strokes: 0},
+		}},
+		{},
+	}
+
+	var p Player
+	p.tallyHoles(rounds)
+	if p.HolesPlayed != 5 || p.Birdies != 2 || p.Eagles != 1 || p.Bogeys != 1 {
+		t.Errorf("tallyHoles = %d holes, %d birdies, %d eagles, %d bogeys; want 5, 2, 1, 1",
+			p.HolesPlayed, p.Birdies, p.Eagles, p.Bogeys)
+	}
+}
+
+func TestParsePosition(t *testing.T) {
+	tests := []struct {
+		in      string
+		rank    int
+		tied    bool
+		special string
+	}{
+		{"1", 1, false, ""},
+		{"T5", 5, true, ""},
+		{" t12 ", 12, true, ""},
+		{"CUT", 0, false, "CUT"},
+		{"wd", 0, false, "WD"},
+		{"-", 0, false, "-"},
+		{"", 0, false, ""},
+	}
+	for _, tt := range tests {
+		rank, tied, special := parsePosition(tt.in)
+		if rank != tt.rank || tied != tt.tied || special != tt.special {
+			t.Errorf("parsePosition(%q) = %d, %v, %q; want %d, %v, %q",
+				tt.in, rank, tied, special, tt.rank, tt.tied, tt.special)
+		}
+	}
+}
+
+func TestSortByPosition(t *testing.T) {
+	players := []Player{
+		{FullName: "A", Rank: 0},
+		{FullName: "B", Rank: 7},
+		{FullName: "C", Rank: 2},
+		{FullName: "D", Rank: 0},
+		{FullName: "Total"},
+	}
+	sortByPosition(players)
+
+	var got []string
+	for _, p := range players {
+		got = append(got, p.FullName)
+	}
+	if want := []string{"C", "B", "A", "D", "Total"}; !slices.Equal(got, want) {
+		t.Errorf("sortByPosition order = %v, want %v", got, want)
+	}
+}
+
+func TestAlignRounds(t *testing.T) {
+	ids := func(rounds []Round) []string {
+		var out []string
+		for _, r := range rounds {
+			out = append(out, r.ScoreToPar)
+		}
+		return out
+	}
+
+	// Out of order with a playoff entry: ordered by roundId, extra dropped.
+	got := alignRounds([]Round{
+		{RoundID: 2, ScoreToPar: "-2"},
+		{RoundID: 1, ScoreToPar: "-1"},
+		{RoundID: 5, ScoreToPar: "-9"},
+		{RoundID: 4, ScoreToPar: "-4"},
+		{RoundID: 3, ScoreToPar: "-3"},
+	}, 4, "Test Player")
+	if want := []string{"-1", "-2", "-3", "-4"}; !slices.Equal(ids(got), want) {
+		t.Errorf("alignRounds by id = %v, want %v", ids(got), want)
+	}
+
+	// A missing id leaves a blank gap rather than pulling R3 into R2, and
+	// a repeated id keeps only its first entry.
+	got = alignRounds([]Round{
+		{RoundID: 1, ScoreToPar: "-1"},
+		{RoundID: 3, ScoreToPar: "-3"},
+		{RoundID: 3, ScoreToPar: "-7"},
+	}, 4, "Test Player")
+	if want := []string{"-1", "", "-3"}; !slices.Equal(ids(got), want) {
+		t.Errorf("alignRounds with a gap and a repeat = %v, want %v", ids(got), want)
+	}
+
+	// Without ids, rounds are kept in feed order and truncated.
+	got = alignRounds([]Round{{ScoreToPar: "-1"}, {ScoreToPar: "-2"}, {ScoreToPar: "-3"}}, 2, "Test Player")
+	if want := []string{"-1", "-2"}; !slices.Equal(ids(got), want) {
+		t.Errorf("alignRounds without ids = %v, want %v", ids(got), want)
+	}
+}
+
+func TestGetTeamScoresRoundIDs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "leaderboard.json")
+	err := os.WriteFile(path, []byte(`{"leaderboardRows": [
+		{"firstName": "Adam", "lastName": "One", "position": "1", "roundComplete": true,
+		 "rounds": [{"roundId": 1, "scoreToPar": "-3"}, {"roundId": 3, "scoreToPar": "-1"}]},
+		{"firstName": "Ben", "lastName": "Two", "position": "2", "roundComplete": true,
+		 "rounds": [{"roundId": 1, "scoreToPar": "-1"}, {"roundId": 2, "scoreToPar": "-2"}, {"roundId": 2, "scoreToPar": "-5"}]}
+	]}`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	team := getTeamScores(mustLoadLeaderboard(t, path), Team{Players: []string{"Adam One", "Ben Two"}}, ScoreOptions{Count: 2})
+
+	byName := map[string]Player{}
+	for _, p := range team {
+		byName[p.FullName] = p
+	}
+	// Round 2 is missing, so it's a gap and round 3 stays in R3.
+	if adam := byName["Adam One"]; adam.Round(2) != "-" || adam.R3 != -1 || adam.Total != -4 || !slices.Equal(adam.Unplayed, []int{2}) {
+		t.Errorf("Adam One R2, R3, Total, Unplayed = %s, %d, %d, %v; want -, -1, -4, [2]", adam.Round(2), adam.R3, adam.Total, adam.Unplayed)
+	}
+	// The repeated round 2 is dropped instead of shifting into R3.
+	if ben := byName["Ben Two"]; ben.R2 != -2 || ben.R3 != 0 || ben.Total != -3 {
+		t.Errorf("Ben Two R2, R3, Total = %d, %d, %d; want -2, 0, -3", ben.R2, ben.R3, ben.Total)
+	}
+}
+
+func TestRenderScoreboardCreatesDirs(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "site", "pool", "index.html")
+	teams := []Team{{TeamName: "Team A", PlayerScores: []Player{{FullName: "Total"}}}}
+	if err := renderScoreboard(newPageData(teams, nil, TournamentMeta{}, ScoreOptions{}), out); err != nil {
+		t.Fatalf("renderScoreboard: %v", err)
+	}
+	if _, err := os.Stat(out); err != nil {
+		t.Errorf("scoreboard not written: %v", err)
+	}
+}
+
+func TestWriteScoreboardLayouts(t *testing.T) {
+	teams := []Team{{TeamName: "Team A", PlayerScores: []Player{{FullName: "Adam One", Total: -6}, {FullName: "Total", Total: -6}}}}
+	for _, tt := range []struct {
+		layout, want string
+	}{
+		{"", "<table>"},
+		{layoutTable, "<table>"},
+		{layoutCompact, `<div class="card"`},
+	} {
+		data := newPageData(teams, nil, TournamentMeta{}, ScoreOptions{})
+		data.Layout = tt.layout
+		var buf strings.Builder
+		if err := writeScoreboard(&buf, data); err != nil {
+			t.Fatalf("layout %q: %v", tt.layout, err)
+		}
+		if !strings.Contains(buf.String(), tt.want) || !strings.Contains(buf.String(), "Team A") {
+			t.Errorf("layout %q page missing %q or the team", tt.layout, tt.want)
+		}
+	}
+}
+
+func TestWriteScoreboardCompactSections(t *testing.T) {
+	teamA := Team{TeamName: "Team A", PlayerScores: []Player{{FullName: "Adam One", R1: -3, R2: 1, Total: -2}, {FullName: "Total", R1: -3, R2: 1, Total: -2}}}
+	teamB := Team{TeamName: "Team B", PlayerScores: []Player{{FullName: "Ben Two", R1: 2, R2: -4, Total: -2}, {FullName: "Total", R1: 2, R2: -4, Total: -2}}}
+	data := newPageData([]Team{teamA, teamB}, []string{"Chuck"}, TournamentMeta{}, ScoreOptions{RankRound: 2})
+	data.Layout = layoutCompact
+	data.Unmatched = []string{"Team A: Nobody Here"}
+	data.LowRound = &LowRound{Score: -4, Players: []string{"Ben Two"}}
+	data.Matchups = []Matchup{{Home: teamA, Away: teamB, HomeTotal: -2, AwayTotal: -2, Result: "tie"}}
+
+	var buf strings.Builder
+	if err := writeScoreboard(&buf, data); err != nil {
+		t.Fatalf("writeScoreboard: %v", err)
+	}
+	page := buf.String()
+	for _, want := range []string{
+		"Not found on the leaderboard: Team A: Nobody Here",
+		"Showing 2 of 3 teams; couldn't load: Chuck",
+		"Low round -4: Ben Two",
+		"Head-to-Head",
+		// Cards show the R2 totals being ranked, not the overall ones.
+		`<span class="total under">-4</span>`,
+		`<span class="total over">&#43;1</span>`,
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("compact page missing %q", want)
+		}
+	}
+}
+
+func TestTeamDisplayDefaults(t *testing.T) {
+	if got := (Team{}).DisplayColor(); got != defaultTeamColor {
+		t.Errorf("DisplayColor() = %q, want %q", got, defaultTeamColor)
+	}
+	if got := (Team{Emoji: "🦅"}).DisplayEmoji(); got != "🦅" {
+		t.Errorf("DisplayEmoji() = %q, want the team's own", got)
+	}
+}
+
+func TestWriteScoreboardNoTimestamp(t *testing.T) {
+	noTimestamp = true
+	defer func() { noTimestamp = false }()
+
+	cfg := Config{TeamsDir: "teams", Leaderboard: "testdata/leaderboard.json", Members: []string{"JR"}}
+	teams, skipped, err := buildTeams(cfg, ScoreOptions{Count: 4, CutPenalty: 3})
+	if err != nil {
+		t.Fatalf("buildTeams: %v", err)
+	}
+	var pages [2]strings.Builder
+	for i := range pages {
+		if i > 0 {
+			time.Sleep(time.Millisecond)
+		}
+		if err := writeScoreboard(&pages[i], newPageData(teams, skipped, TournamentMeta{}, ScoreOptions{})); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if pages[0].String() != pages[1].String() {
+		t.Error("two renders of the same data differ")
+	}
+	if strings.Contains(pages[0].String(), "Last updated") {
+		t.Error("page has a timestamp with noTimestamp set")
+	}
+}
+
+func TestCountingPlayersOnly(t *testing.T) {
+	team := getTeamScores(mustLoadLeaderboard(t, "testdata/leaderboard.json"), Team{Players: []string{"Adam One", "Ben Two", "Carl Three"}, Excluded: []string{"Ben Two"}}, ScoreOptions{Count: 1, CutPenalty: 3})
+	teams := []Team{{TeamName: "Team A", PlayerScores: team}}
+
+	shown := countingPlayersOnly(teams)
+	var names []string
+	for _, p := range shown[0].PlayerScores {
+		names = append(names, p.FullName)
+	}
+	if got := strings.Join(names, ","); got != "Adam One,Total" {
+		t.Errorf("shown players = %s, want Adam One,Total", got)
+	}
+	if got := teamGrandTotal(shown[0]); got != -6 {
+		t.Errorf("shown total = %d, want -6", got)
+	}
+	if len(teams[0].PlayerScores) != 4 {
+		t.Errorf("original team has %d rows, want all 4 kept", len(teams[0].PlayerScores))
+	}
+}
+
+func TestRenderScoreboardCheckHTML(t *testing.T) {
+	checkHTML = true
+	defer func() { checkHTML = false }()
+
+	cfg := Config{TeamsDir: "teams", Leaderboard: "testdata/leaderboard.json", Members: []string{"JR"}}
+	teams, skipped, err := buildTeams(cfg, ScoreOptions{Count: 4, CutPenalty: 3})
+	if err != nil {
+		t.Fatalf("buildTeams: %v", err)
+	}
+	out := filepath.Join(t.TempDir(), "index.html")
+	for _, layout := range []string{layoutTable, layoutCompact} {
+		data := newPageData(teams, skipped, TournamentMeta{}, ScoreOptions{})
+		data.Layout = layout
+		if err := renderScoreboard(data, out); err != nil {
+			t.Errorf("%s layout fails the check: %v", layout, err)
+		}
+	}
+
+	// A template with an unclosed table is rejected and the page kept.
+	broken := filepath.Join(t.TempDir(), "broken.html")
+	page := "<!DOCTYPE html><html><body><table><tr><td>{{ len .Teams }}</td></tr>" + strings.Repeat(" ", minPageSize) + "</body></html>"
+	if err := os.WriteFile(broken, []byte(page), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	templatePath = broken
+	defer func() { templatePath = "" }()
+	err = renderScoreboard(newPageData(teams, skipped, TournamentMeta{}, ScoreOptions{}), out)
+	if err == nil || !strings.Contains(err.Error(), "<table> (+1)") {
+		t.Errorf("renderScoreboard with an unclosed table = %v, want an unbalanced tag error", err)
+	}
+	if got, _ := os.ReadFile(out); !strings.Contains(string(got), "</table>") {
+		t.Error("broken page replaced the previous one")
+	}
+}
+
+func TestScoringTrend(t *testing.T) {
+	tests := []struct {
+		played []int
+		avg    float64
+		trend  string
+	}{
+		{nil, 0, ""},
+		{[]int{-2}, -2, ""},
+		{[]int{-3, -2, -1, 0}, -1.5, "declining"},
+		{[]int{1, 1, 1, 0}, 0.75, "improving"},
+		{[]int{-1, 2, -1}, 0, "steady"},
+	}
+	for _, tt := range tests {
+		avg, trend := scoringTrend(tt.played)
+		if avg != tt.avg || trend != tt.trend {
+			t.Errorf("scoringTrend(%v) = %v, %q; want %v, %q", tt.played, avg, trend, tt.avg, tt.trend)
+		}
+	}
+}
+
+func TestGetTeamScoresAmateur(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "leaderboard.json")
+	leaderboard := `{"leaderboardRows": [
+		{"firstName": "Nick", "lastName": "Dunlap (a)", "position": "T3", "roundComplete": true, "rounds": [{"scoreToPar": "-4"}]},
+		{"firstName": "Gordon", "lastName": "Sargent", "position": "T9", "roundComplete": true, "rounds": [{"scoreToPar": "-1"}]}
+	]}`
+	if err := os.WriteFile(path, []byte(leaderboard), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	team := getTeamScores(mustLoadLeaderboard(t, path), Team{Players: []string{"Nick Dunlap", "Gordon Sargent (a)"}}, ScoreOptions{Count: 2})
+	if len(team) != 3 {
+		t.Fatalf("got %d rows, want both players and a Total", len(team))
+	}
+	if team[0].FullName != "Nick Dunlap" || team[0].R1 != -4 {
+		t.Errorf("team[0] = %+v, want Nick Dunlap at -4", team[0])
+	}
+	if team[1].FullName != "Gordon Sargent (a)" || team[1].R1 != -1 {
+		t.Errorf("team[1] = %+v, want Gordon Sargent (a) at -1", team[1])
+	}
+}
+
+func TestGetTeamScoresDuplicateNames(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "leaderboard.json")
+	leaderboard := `{"leaderboardRows": [
+		{"playerId": "101", "firstName": "Sam", "lastName": "Smith", "position": "T3", "roundComplete": true, "rounds": [{"scoreToPar": "-4"}]},
+		{"playerId": "202", "firstName": "Sam", "lastName": "Smith", "position": "T40", "roundComplete": true, "rounds": [{"scoreToPar": "+2"}]}
+	]}`
+	if err := os.WriteFile(path, []byte(leaderboard), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		ids  map[string]string
+		want int
+	}{
+		{"first match without an id", nil, -4},
+		{"matched by id", map[string]string{"Sam Smith": "202"}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			team := getTeamScores(mustLoadLeaderboard(t, path), Team{Players: []string{"Sam Smith"}, PlayerIDs: tt.ids}, ScoreOptions{Count: 1})
+			if team[0].R1 != tt.want {
+				t.Errorf("R1 = %d, want %d", team[0].R1, tt.want)
+			}
+		})
+	}
+
+	team := getTeamScores(mustLoadLeaderboard(t, path), Team{Players: []string{"Sam Smith"}, PlayerIDs: map[string]string{"Sam Smith": "999"}}, ScoreOptions{Count: 1})
+	if len(team) != 1 {
+		t.Errorf("got %d rows, want only the Total for an unknown playerId", len(team))
+	}
+}
+
+func TestScoreTeams(t *testing.T) {
+	prev := scoreWorkers
+	scoreWorkers = 2
+	defer func() { scoreWorkers = prev }()
+
+	teams := []Team{
+		{TeamName: "A", Players: []string{"Adam One"}},
+		{TeamName: "B", Players: []string{"Ben Two"}},
+		{TeamName: "C", Players: []string{"Carl Three"}},
+	}
+	scores := scoreTeams(mustLoadLeaderboard(t, "testdata/leaderboard.json"), teams, ScoreOptions{Count: 4, CutPenalty: 3})
+	for i, want := range []string{"Adam One", "Ben Two", "Carl Three"} {
+		if got := scores[i][0].FullName; got != want {
+			t.Errorf("scores[%d] is for %s, want %s", i, got, want)
+		}
+	}
+}
+
+func TestBubbleMargin(t *testing.T) {
+	// Adam One (-6) and Ben Two (-4) count; Carl Three (+2) is the bubble.
+	team := getTeamScores(mustLoadLeaderboard(t, "testdata/leaderboard.json"), Team{Players: []string{"Adam One", "Ben Two", "Carl Three", "Dan Four"}}, ScoreOptions{Count: 2, CutPenalty: 3})
+	if got := bubbleMargin(team); got == nil || *got != 6 {
+		t.Errorf("bubbleMargin = %v, want 6", got)
+	}
+
+	team = getTeamScores(mustLoadLeaderboard(t, "testdata/leaderboard.json"), Team{Players: []string{"Adam One", "Ben Two"}}, ScoreOptions{Count: 4, CutPenalty: 3})
+	if got := bubbleMargin(team); got != nil {
+		t.Errorf("bubbleMargin with everyone counted = %d, want nil", *got)
+	}
+}
+
+func TestGetTeamScoresSubstitutes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "leaderboard.json")
+	leaderboard := `{"leaderboardRows": [
+		{"firstName": "Will", "lastName": "Drew", "position": "WD", "rounds": []},
+		{"firstName": "Late", "lastName": "Scratch", "position": "WD", "rounds": [{"scoreToPar": "+4"}]},
+		{"firstName": "Sub", "lastName": "One", "position": "T5", "roundComplete": true, "rounds": [{"scoreToPar": "-2"}]},
+		{"firstName": "Sub", "lastName": "Two", "position": "T9", "roundComplete": true, "rounds": [{"scoreToPar": "-1"}]}
+	]}`
+	if err := os.WriteFile(path, []byte(leaderboard), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	picks := Team{
+		Players:     []string{"Will Drew", "Late Scratch", "Not Here"},
+		Substitutes: []string{"Sub One", "Sub Two", "Sub Three"},
+	}
+	team := getTeamScores(mustLoadLeaderboard(t, path), picks, ScoreOptions{Count: 4})
+
+	subs := map[string]string{}
+	for _, p := range team {
+		subs[p.FullName] = p.SubstituteFor
+	}
+	want := map[string]string{"Sub One": "Will Drew", "Late Scratch": "", "Sub Two": "Not Here", "Total": ""}
+	if len(subs) != len(want) {
+		t.Fatalf("scored %v, want %v", subs, want)
+	}
+	for name, subFor := range want {
+		if got, ok := subs[name]; !ok || got != subFor {
+			t.Errorf("%s SubstituteFor = %q, want %q", name, got, subFor)
+		}
+	}
+	if got := unmatchedPicks(picks.Players, team); len(got) != 0 {
+		t.Errorf("unmatchedPicks = %v, want none once substituted", got)
+	}
+}
+
+func TestUnmatchedPicks(t *testing.T) {
+	team := getTeamScores(mustLoadLeaderboard(t, "testdata/leaderboard.json"), Team{Players: []string{"Adam One", "Adm Two", "Ben Two"}}, ScoreOptions{Count: 4, CutPenalty: 3})
+	if got := unmatchedPicks([]string{"Adam One", "Adm Two", "Ben Two"}, team); !slices.Equal(got, []string{"Adm Two"}) {
+		t.Errorf("unmatchedPicks = %v, want [Adm Two]", got)
+	}
+}
+
+func TestRenderScoreboardKeepsPageOnError(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "index.html")
+	if err := os.WriteFile(out, []byte("previous page"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	bad := filepath.Join(t.TempDir(), "bad.html")
+	if err := os.WriteFile(bad, []byte("{{ .NoSuchField }}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	templatePath = bad
+	defer func() { templatePath = "" }()
+
+	if err := renderScoreboard(PageData{}, out); err == nil {
+		t.Fatal("renderScoreboard with a broken template succeeded, want error")
+	}
+	if got, _ := os.ReadFile(out); string(got) != "previous page" {
+		t.Errorf("page after failed render = %q, want it untouched", got)
+	}
+	if entries, _ := os.ReadDir(filepath.Dir(out)); len(entries) != 1 {
+		t.Errorf("render left %d files behind, want just index.html", len(entries))
+	}
+}
+
+func TestValidateTeamComposition(t *testing.T) {
+	rules := CompositionRules{MaxPerTier: map[int]int{1: 1, 2: 2}}
+	team := Team{
+		TeamName: "Team A",
+		Players:  []string{"Scottie Scheffler", "Rory McIlroy", "Tom Kim", "Max Homa", "Sam Burns"},
+		Tiers:    map[string]int{"Scottie Scheffler": 1, "Rory McIlroy": 1, "Tom Kim": 2, "Max Homa": 2},
+	}
+
+	err := validateTeamComposition(team, rules)
+	if err == nil {
+		t.Fatal("validateTeamComposition with two tier 1 players succeeded, want error")
+	}
+	if !strings.Contains(err.Error(), "2 tier 1 players (Scottie Scheffler, Rory McIlroy), max 1") {
+		t.Errorf("error = %q, want it to name the tier 1 players", err)
+	}
+	if strings.Contains(err.Error(), "tier 2") {
+		t.Errorf("error = %q, tier 2 is within its limit", err)
+	}
+
+	team.Tiers["Rory McIlroy"] = 3
+	if err := validateTeamComposition(team, rules); err != nil {
+		t.Errorf("validateTeamComposition = %v, want nil", err)
+	}
+}
+
+func TestGetTeamScoresRoundGap(t *testing.T) {
+	team := getTeamScores(mustLoadLeaderboard(t, "testdata/leaderboard-gap.json"), Team{Players: []string{"Gus Gap"}}, ScoreOptions{Count: 4, CutPenalty: 3, Project: true})
+
+	// R2 is a blank placeholder between two real rounds: it shows as
+	// unplayed and stays out of the average, best round, and projection.
+	p := team[0]
+	if got := p.Round(2); got != "-" {
+		t.Errorf("Round(2) = %q, want -", got)
+	}
+	if !slices.Equal(p.Unplayed, []int{2}) {
+		t.Errorf("Unplayed = %v, want [2]", p.Unplayed)
+	}
+	if p.Total != 0 || p.RoundsPlayed != 2 || p.Average != 0 {
+		t.Errorf("Total, RoundsPlayed, Average = %d, %d, %.1f; want 0, 2, 0.0", p.Total, p.RoundsPlayed, p.Average)
+	}
+	if best, worst := bestWorstRound(p); best != -1 || worst != 1 {
+		t.Errorf("bestWorstRound = %d, %d; want -1, 1", best, worst)
+	}
+	if got := projectTotal(p, 1, 4); got != 2 {
+		t.Errorf("projectTotal = %d, want 2 (two unplayed rounds at the field average)", got)
+	}
+
+	// The field average is over the three real rounds, +1, -1, and -2,
+	// not the blank or "-" placeholders.
+	if got := fieldAverage(mustLoadLeaderboard(t, "testdata/leaderboard-gap.json").LeaderboardRows); got != -2.0/3 {
+		t.Errorf("fieldAverage = %.2f, want -0.67", got)
+	}
+}
+
+func TestGetTeamScoresRoundGapOverride(t *testing.T) {
+	tests := []struct {
+		override     ScoreOverride
+		played       int
+		average      float64
+		best, worst  int
+		wantUnplayed []int
+	}{
+		// Filling the gap makes it a played round.
+		{ScoreOverride{Player: "Gus Gap", Round: 2, Score: -2}, 3, -2.0 / 3, -2, 1, nil},
+		// Correcting the round after the gap leaves the gap alone.
+		{ScoreOverride{Player: "Gus Gap", Round: 3, Score: -4}, 2, -1.5, -4, 1, []int{2}},
+	}
+	for _, tt := range tests {
+		opts := ScoreOptions{Count: 4, CutPenalty: 3, Overrides: []ScoreOverride{tt.override}}
+		team := getTeamScores(mustLoadLeaderboard(t, "testdata/leaderboard-gap.json"), Team{Players: []string{"Gus Gap"}}, opts)
+		p := team[0]
+		if p.RoundsPlayed != tt.played || math.Abs(p.Average-tt.average) > 1e-9 || !slices.Equal(p.Unplayed, tt.wantUnplayed) {
+			t.Errorf("R%d override: RoundsPlayed, Average, Unplayed = %d, %.2f, %v; want %d, %.2f, %v",
+				tt.override.Round, p.RoundsPlayed, p.Average, p.Unplayed, tt.played, tt.average, tt.wantUnplayed)
+		}
+		if p.BestRound != tt.best || p.WorstRound != tt.worst {
+			t.Errorf("R%d override: BestRound, WorstRound = %d, %d; want %d, %d", tt.override.Round, p.BestRound, p.WorstRound, tt.best, tt.worst)
+		}
+	}
+}
+
+func TestGetTeamScoresTrustTotal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "leaderboard.json")
+	err := os.WriteFile(path, []byte(`{"cutLines": [{"cutScore": "+2"}], "leaderboardRows": [
+		{"firstName": "Adam", "lastName": "One", "position": "2", "roundComplete": true,
+		 "rounds": [{"roundId": 1, "scoreToPar": "-3"}, {"roundId": 2, "scoreToPar": "-2"}], "total": "-6"},
+		{"firstName": "Eric", "lastName": "Five", "position": "CUT", "roundComplete": true,
+		 "rounds": [{"roundId": 1, "scoreToPar": "+1"}, {"roundId": 2, "scoreToPar": "+2"}], "total": "+4"}
+	]}`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	picks := Team{Players: []string{"Adam One", "Eric Five"}}
+
+	// The cut player's two +5 penalty rounds count either way.
+	for _, tt := range []struct {
+		trust      bool
+		adam, eric int
+	}{
+		{false, -5, 13},
+		{true, -6, 14},
+	} {
+		team := getTeamScores(mustLoadLeaderboard(t, path), picks, ScoreOptions{Count: 2, CutPenalty: 3, TrustTotal: tt.trust})
+		got := map[string]int{}
+		for _, p := range team {
+			got[p.FullName] = p.Total
+		}
+		if got["Adam One"] != tt.adam || got["Eric Five"] != tt.eric || got["Total"] != tt.adam+tt.eric {
+			t.Errorf("TrustTotal %v: totals = %v, want Adam One %d, Eric Five %d", tt.trust, got, tt.adam, tt.eric)
+		}
+		if team[0].FeedTotal == nil || *team[0].FeedTotal != -6 {
+			t.Errorf("TrustTotal %v: Adam One FeedTotal = %v, want -6", tt.trust, team[0].FeedTotal)
+		}
+	}
+}
+
+func TestGetTeamScoresRoundWeights(t *testing.T) {
+	// R4 counts double: Ben Two's -1 becomes -2, Adam One's E stays E.
+	opts := ScoreOptions{Count: 2, CutPenalty: 3, RoundWeights: []int{1, 1, 1, 2}}
+	team := getTeamScores(mustLoadLeaderboard(t, "testdata/leaderboard.json"), Team{Players: []string{"Adam One", "Ben Two"}}, opts)
+	want := map[string]int{"Adam One": -6, "Ben Two": -5, "Total": -11}
+	for _, p := range team {
+		if p.Total != want[p.FullName] {
+			t.Errorf("%s Total = %d, want %d", p.FullName, p.Total, want[p.FullName])
+		}
+	}
+	if got := (PageData{RoundWeights: weightsShown(opts)}).WeightSummary(); got != "R1 ×1, R2 ×1, R3 ×1, R4 ×2" {
+		t.Errorf("WeightSummary = %q", got)
+	}
+	if weightsShown(ScoreOptions{RoundWeights: []int{1, 1, 1, 1}}) != nil {
+		t.Error("all-1 weights are shown on the scoreboard")
+	}
+
+	// The running totals stay unweighted, so the page says so.
+	var buf strings.Builder
+	if err := writeScoreboard(&buf, newPageData([]Team{{TeamName: "Team A", PlayerScores: team}}, nil, TournamentMeta{}, opts)); err != nil {
+		t.Fatalf("writeScoreboard: %v", err)
+	}
+	if page := buf.String(); !strings.Contains(page, "After R3 (unweighted)") || !strings.Contains(page, "Weighted Total") {
+		t.Error("weighted page doesn't mark the running totals unweighted")
+	}
+}
+
+func TestProjectCut(t *testing.T) {
+	row := func(position, total string, rounds int) LeaderboardRow {
+		return LeaderboardRow{Position: position, Total: total, Rounds: make([]Round, rounds)}
+	}
+	field := func(n int) []LeaderboardRow {
+		var rows []LeaderboardRow
+		for i := range n {
+			rows = append(rows, row("T1", formatToPar(i/10-2), 1))
+		}
+		return rows
+	}
+	withCut := func(lb Leaderboard, cut string) *Leaderboard {
+		lb.CutLines = append(lb.CutLines, struct {
+			CutScore string `json:"cutScore"`
+		}{cut})
+		return &lb
+	}
+
+	tests := []struct {
+		name        string
+		leaderboard *Leaderboard
+		want        int
+		wantOK      bool
+	}{
+		// 70 players at -2 through +4, ten to a score: 65th place is +4.
+		{"computed from the field", &Leaderboard{LeaderboardRows: field(70)}, 4, true},
+		{"the feed's projection wins", withCut(Leaderboard{LeaderboardRows: field(70)}, "+1"), 1, true},
+		{"field no bigger than the cut", &Leaderboard{LeaderboardRows: field(65)}, 0, false},
+		{"cut already made", withCut(Leaderboard{LeaderboardRows: append(field(70), row("CUT", "+6", 2))}, "+3"), 0, false},
+		{"into the weekend", &Leaderboard{LeaderboardRows: append(field(70), row("1", "-9", 3))}, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := projectCut(tt.leaderboard)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("%s: projectCut = %d, %v; want %d, %v", tt.name, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestGetTeamScoresInsideCut(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "leaderboard.json")
+	err := os.WriteFile(path, []byte(`{"cutLines": [{"cutScore": "+1"}], "leaderboardRows": [
+		{"firstName": "Adam", "lastName": "One", "position": "T10", "roundComplete": true, "rounds": [{"roundId": 1, "scoreToPar": "+1"}], "total": "+1"},
+		{"firstName": "Ben", "lastName": "Two", "position": "T70", "roundComplete": true, "rounds": [{"roundId": 1, "scoreToPar": "+2"}], "total": "+2"},
+		{"firstName": "Carl", "lastName": "Three", "position": "WD", "roundComplete": true, "rounds": [{"roundId": 1, "scoreToPar": "+8"}], "total": "+8"}
+	]}`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	team := getTeamScores(mustLoadLeaderboard(t, path), Team{Players: []string{"Adam One", "Ben Two", "Carl Three"}}, ScoreOptions{Count: 3, CutPenalty: 3})
+
+	inside := map[string]bool{}
+	for _, p := range team {
+		if p.ProjectedCut != nil {
+			inside[p.FullName] = p.InsideCut
+		}
+	}
+	if len(inside) != 2 || !inside["Adam One"] || inside["Ben Two"] {
+		t.Errorf("InsideCut = %v, want Adam One inside and Ben Two outside, Carl Three (WD) unmarked", inside)
+	}
+	if got := teamsProjectedCut([]Team{{PlayerScores: team}}); got == nil || *got != 1 {
+		t.Errorf("teamsProjectedCut = %v, want +1", got)
+	}
+}
+
+func TestSelectTeam(t *testing.T) {
+	players := []Player{
+		{FullName: "Ben Two", R1: -4, Total: -4, Gross: -4, Rank: 3},
+		{FullName: "Carl Three", R1: 2, Total: 2, Gross: 2, Rank: 9},
+		{FullName: "Adam One", R1: -6, Total: -6, Gross: -6, Rank: 2},
+		{FullName: "Dan Four", R1: -9, Total: -9, Gross: -9, Rank: 1, Scratched: true},
+		{FullName: "Eric Five", R1: -4, Total: -4, Gross: -4, Rank: 4},
+	}
+
+	// Ben Two and Eric Five tie for the last counting spot; the one listed
+	// first keeps it. The scratched Dan Four never counts.
+	team := selectTeam(slices.Clone(players), ScoreOptions{Count: 2})
+	var got []string
+	for _, p := range team {
+		switch {
+		case p.Excluded:
+			got = append(got, p.FullName+" (excluded)")
+		case p.Scratched:
+			got = append(got, p.FullName+" (scratched)")
+		default:
+			got = append(got, p.FullName)
+		}
+	}
+	want := "Adam One, Ben Two, Eric Five (excluded), Carl Three (excluded), Dan Four (scratched), Total"
+	if strings.Join(got, ", ") != want {
+		t.Errorf("selectTeam = %s, want %s", strings.Join(got, ", "), want)
+	}
+	if total := team[len(team)-1]; total.Total != -10 || total.R1 != -10 {
+		t.Errorf("Total row = %d (R1 %d), want -10", total.Total, total.R1)
+	}
+
+	team = selectTeam(slices.Clone(players), ScoreOptions{Count: 2, ByPosition: true})
+	if team[0].FullName != "Dan Four" || team[len(team)-1].FullName != "Total" {
+		t.Errorf("ByPosition order starts %s and ends %s, want Dan Four and Total", team[0].FullName, team[len(team)-1].FullName)
+	}
+}
+
+func TestBestWorstRound(t *testing.T) {
+	team := getTeamScores(mustLoadLeaderboard(t, "testdata/leaderboard.json"), Team{Players: []string{"Adam One", "Eric Five", "Hal Eight"}}, ScoreOptions{Count: 4, CutPenalty: 3})
+
+	// Eric Five's +5 cut fills don't count as his worst round.
+	want := map[string][2]int{"Adam One": {-3, 0}, "Eric Five": {2, 3}, "Hal Eight": {1, 1}}
+	for _, p := range team[:len(team)-1] {
+		best, worst := bestWorstRound(p)
+		if w := want[p.FullName]; best != w[0] || worst != w[1] {
+			t.Errorf("bestWorstRound(%s) = %d, %d; want %d, %d", p.FullName, best, worst, w[0], w[1])
+		}
+	}
+
+	low := lowRound([]Team{{PlayerScores: team}, {PlayerScores: team[:1]}})
+	if low == nil || low.Score != -3 || !slices.Equal(low.Players, []string{"Adam One"}) {
+		t.Errorf("lowRound = %+v, want Adam One at -3", low)
+	}
+}
+
+func TestPlayerCounts(t *testing.T) {
+	team := getTeamScores(mustLoadLeaderboard(t, "testdata/leaderboard.json"), Team{Players: []string{"Adam One", "Eric Five", "Ben Two", "Carl Three"}, Excluded: []string{"Carl Three"}}, ScoreOptions{Count: 4, CutPenalty: 3})
+	if active, cut := playerCounts(team); active != 2 || cut != 1 {
+		t.Errorf("playerCounts = %d active, %d cut; want 2, 1", active, cut)
+	}
+}
+
+func TestStatusBanner(t *testing.T) {
+	tests := []struct {
+		status string
+		round  int
+		want   string
+	}{
+		{"In Progress", 3, "LIVE — Round 3"},
+		{"Official", 4, "FINAL"},
+		{"Suspended", 2, "SUSPENDED — Round 2"},
+		{"Not Started", 0, "UPCOMING"},
+		{"Something Else", 1, ""},
+	}
+	for _, tt := range tests {
+		meta := TournamentMeta{Status: parseTournamentStatus(tt.status), Round: tt.round}
+		if got := meta.StatusBanner(); got != tt.want {
+			t.Errorf("StatusBanner(%q, %d) = %q, want %q", tt.status, tt.round, got, tt.want)
+		}
+	}
+}
+
+func TestFindTeam(t *testing.T) {
+	teams := []Team{{TeamName: "Team Brüno"}, {TeamName: "The Short Kings"}}
+	if got, err := findTeam(teams, "team bruno"); err != nil || got.TeamName != "Team Brüno" {
+		t.Errorf("findTeam(team bruno) = %q, %v", got.TeamName, err)
+	}
+	if _, err := findTeam(teams, "Team Nobody"); err == nil || !strings.Contains(err.Error(), "The Short Kings") {
+		t.Errorf("findTeam(Team Nobody) error = %v, want it to list the teams", err)
+	}
+}
+
+func TestDiscoverTeams(t *testing.T) {
+	got, err := discoverTeams("teams")
+	if err != nil {
+		t.Fatalf("discoverTeams: %v", err)
+	}
+	if want := []string{"Alex", "Chuck", "JR", "Matt", "Pat"}; !slices.Equal(got, want) {
+		t.Errorf("discoverTeams = %v, want %v", got, want)
+	}
+	if _, err := discoverTeams(t.TempDir()); err == nil {
+		t.Error("discoverTeams on an empty directory succeeded, want error")
+	}
+}
+
+func TestPlayerTotalMatchesTotals(t *testing.T) {
+	names := []string{"Adam One", "Ben Two", "Carl Three", "Dan Four", "Eric Five", "Frank Six", "Gary Seven", "Hal Eight"}
+	for _, opts := range []ScoreOptions{
+		{Count: 4, CutPenalty: 3},
+		{Count: 4, CutPenalty: 3, Rounds: []int{3, 4}},
+	} {
+		team := getTeamScores(mustLoadLeaderboard(t, "testdata/leaderboard.json"), Team{Players: names}, opts)
+
+		sum := 0
+		for _, p := range team {
+			if got := playerTotal(p, opts); got != p.Total {
+				t.Errorf("rounds %v: %s Total = %d, playerTotal = %d", opts.Rounds, p.FullName, p.Total, got)
+			}
+			if p.FullName != "Total" && !p.Excluded {
+				sum += p.Total
+			}
+		}
+		if total := team[len(team)-1].Total; total != sum {
+			t.Errorf("rounds %v: team Total = %d, counted players sum to %d", opts.Rounds, total, sum)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Pairing is one head-to-head matchup in a pairings file, naming both teams
+// by their teamName.
+type Pairing struct {
+	Home string `json:"home"`
+	Away string `json:"away"`
+}
+
+// Matchup is a scored Pairing. Result is the home team's result from
+// scoreMatchup, and Margin is how many strokes separate the teams.
+type Matchup struct {
+	Home      Team
+	Away      Team
+	HomeTotal int
+	AwayTotal int
+	Result    string
+	Margin    int
+}
+
+// loadPairings reads a JSON array of pairings from filePath.
+func loadPairings(filePath string) ([]Pairing, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var pairings []Pairing
+	if err := json.Unmarshal(data, &pairings); err != nil {
+		return nil, fmt.Errorf("%s: %v", filePath, err)
+	}
+	return pairings, nil
+}
+
+// scoreMatchup returns "win", "loss", or "tie" for a against b, comparing
+// grand totals; the lower total wins.
+func scoreMatchup(a, b Team) string {
+	switch ta, tb := teamGrandTotal(a), teamGrandTotal(b); {
+	case ta < tb:
+		return "win"
+	case ta > tb:
+		return "loss"
+	}
+	return "tie"
+}
+
+// matchups scores each pairing against teams. Every team a pairing names
+// must be among teams.
+func matchups(pairings []Pairing, teams []Team) ([]Matchup, error) {
+	byName := make(map[string]Team, len(teams))
+	for _, t := range teams {
+		byName[t.TeamName] = t
+	}
+
+	var out []Matchup
+	for _, p := range pairings {
+		home, ok := byName[p.Home]
+		if !ok {
+			return nil, fmt.Errorf("pairing %s vs %s: no team named %q", p.Home, p.Away, p.Home)
+		}
+		away, ok := byName[p.Away]
+		if !ok {
+			return nil, fmt.Errorf("pairing %s vs %s: no team named %q", p.Home, p.Away, p.Away)
+		}
+		homeTotal, awayTotal := teamGrandTotal(home), teamGrandTotal(away)
+		out = append(out, Matchup{
+			Home:      home,
+			Away:      away,
+			HomeTotal: homeTotal,
+			AwayTotal: awayTotal,
+			Result:    scoreMatchup(home, away),
+			Margin:    abs(homeTotal - awayTotal),
+		})
+	}
+	return out, nil
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
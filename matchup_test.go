@@ -0,0 +1,35 @@
+package main
+
+import "testing"
+
+func TestScoreMatchup(t *testing.T) {
+	team := func(name string, total int) Team {
+		return Team{TeamName: name, PlayerScores: []Player{{FullName: "Total", Total: total}}}
+	}
+	a, b, c := team("Team A", -10), team("Team B", -7), team("Team C", -10)
+
+	tests := []struct {
+		a, b Team
+		want string
+	}{
+		{a, b, "win"},
+		{b, a, "loss"},
+		{a, c, "tie"},
+	}
+	for _, tt := range tests {
+		if got := scoreMatchup(tt.a, tt.b); got != tt.want {
+			t.Errorf("scoreMatchup(%s, %s) = %q, want %q", tt.a.TeamName, tt.b.TeamName, got, tt.want)
+		}
+	}
+
+	ms, err := matchups([]Pairing{{Home: "Team B", Away: "Team A"}}, []Team{a, b, c})
+	if err != nil {
+		t.Fatalf("matchups: %v", err)
+	}
+	if ms[0].Result != "loss" || ms[0].Margin != 3 {
+		t.Errorf("matchup = %s by %d, want loss by 3", ms[0].Result, ms[0].Margin)
+	}
+	if _, err := matchups([]Pairing{{Home: "Team A", Away: "Team Z"}}, []Team{a}); err == nil {
+		t.Error("matchups with an unknown team succeeded, want error")
+	}
+}
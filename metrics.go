@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sync/atomic"
+	"time"
+)
+
+// metrics holds the counters served at /metrics. They're process-wide, so
+// they only mean much for a long-running -serve or -interval process.
+var metrics struct {
+	fetchSuccesses  atomic.Int64
+	fetchFailures   atomic.Int64
+	lastFetch       atomic.Int64 // Unix seconds of the last successful fetch
+	playersNotFound atomic.Int64
+}
+
+// recordFetch counts the outcome of one leaderboard fetch.
+func recordFetch(err error) {
+	if err != nil {
+		metrics.fetchFailures.Add(1)
+		return
+	}
+	metrics.fetchSuccesses.Add(1)
+	metrics.lastFetch.Store(time.Now().Unix())
+}
+
+// writeMetrics writes the counters to w in the Prometheus text format.
+func writeMetrics(w io.Writer) error {
+	_, err := fmt.Fprintf(w, `# HELP pga_tracker_fetches_total Leaderboard fetches from the API by result.
+# TYPE pga_tracker_fetches_total counter
+pga_tracker_fetches_total{result="success"} %d
+pga_tracker_fetches_total{result="failure"} %d
+# HELP pga_tracker_last_fetch_timestamp_seconds Unix time of the last successful leaderboard fetch.
+# TYPE pga_tracker_last_fetch_timestamp_seconds gauge
+pga_tracker_last_fetch_timestamp_seconds %d
+# HELP pga_tracker_players_not_found_total Team players missing from the leaderboard, counted on every scoring run.
+# TYPE pga_tracker_players_not_found_total counter
+pga_tracker_players_not_found_total %d
+`,
+		metrics.fetchSuccesses.Load(),
+		metrics.fetchFailures.Load(),
+		metrics.lastFetch.Load(),
+		metrics.playersNotFound.Load())
+	return err
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestWriteMetrics(t *testing.T) {
+	before := metrics.fetchFailures.Load()
+	recordFetch(errors.New("boom"))
+	if got := metrics.fetchFailures.Load(); got != before+1 {
+		t.Errorf("fetch failures = %d, want %d", got, before+1)
+	}
+
+	var b strings.Builder
+	if err := writeMetrics(&b); err != nil {
+		t.Fatalf("writeMetrics: %v", err)
+	}
+	for _, want := range []string{
+		`pga_tracker_fetches_total{result="failure"} `,
+		"# TYPE pga_tracker_last_fetch_timestamp_seconds gauge",
+		"pga_tracker_players_not_found_total ",
+	} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("metrics output missing %q:\n%s", want, b.String())
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+var notifyClient = &http.Client{Timeout: 10 * time.Second}
+
+// Notifiers selects the chat webhooks that recordStandings posts to when the
+// team order changes. Tournament names the event in message headers.
+type Notifiers struct {
+	Discord    bool
+	Slack      bool
+	Tournament string
+}
+
+// any reports whether any notifier is enabled.
+func (n Notifiers) any() bool {
+	return n.Discord || n.Slack
+}
+
+// standingsLines formats teams as the numbered "1. Team (-9)" lines every
+// notifier posts.
+func standingsLines(teams []Team) []string {
+	lines := make([]string, len(teams))
+	for i, t := range teams {
+		lines[i] = fmt.Sprintf("%d. %s (%s)", i+1, t.TeamName, formatToPar(teamGrandTotal(t)))
+	}
+	return lines
+}
+
+// leadMargin returns how many strokes the first of teams leads the second
+// by, or 0 when they're tied or there's only one team.
+func leadMargin(teams []Team) int {
+	if len(teams) < 2 {
+		return 0
+	}
+	return teamGrandTotal(teams[1]) - teamGrandTotal(teams[0])
+}
+
+// notifyDiscord posts the new standings to the webhook in
+// DISCORD_WEBHOOK_URL, calling out a new leader when the top spot changed.
+func notifyDiscord(teams []Team, prev []SnapshotTeam) error {
+	webhook := os.Getenv("DISCORD_WEBHOOK_URL")
+	if webhook == "" {
+		return fmt.Errorf("DISCORD_WEBHOOK_URL is not set")
+	}
+	if len(teams) == 0 {
+		return nil
+	}
+
+	var b strings.Builder
+	if len(prev) == 0 || prev[0].TeamName != teams[0].TeamName {
+		fmt.Fprintf(&b, "🚨 **%s** takes the lead!\n", teams[0].TeamName)
+	} else {
+		b.WriteString("📊 The standings have shifted.\n")
+	}
+	for _, line := range standingsLines(teams) {
+		b.WriteString(line + "\n")
+	}
+
+	payload, err := json.Marshal(map[string]string{"content": b.String()})
+	if err != nil {
+		return err
+	}
+	return postWebhook(webhook, payload)
+}
+
+// slackBlock is one Block Kit block of a Slack message. Section and header
+// blocks set Text; context blocks set Elements.
+type slackBlock struct {
+	Type     string      `json:"type"`
+	Text     *slackText  `json:"text,omitempty"`
+	Elements []slackText `json:"elements,omitempty"`
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// notifySlack posts the standings to the webhook in SLACK_WEBHOOK_URL as a
+// Block Kit message: the leader and their margin, the same standings lines
+// Discord gets, and when they were scored. The header names tournament.
+func notifySlack(teams []Team, tournament string) error {
+	webhook := os.Getenv("SLACK_WEBHOOK_URL")
+	if webhook == "" {
+		return fmt.Errorf("SLACK_WEBHOOK_URL is not set")
+	}
+	if len(teams) == 0 {
+		return nil
+	}
+
+	var lead string
+	switch margin := leadMargin(teams); {
+	case len(teams) == 1:
+		lead = fmt.Sprintf("🏆 *%s* leads", teams[0].TeamName)
+	case margin == 0:
+		lead = fmt.Sprintf("🏆 *%s* is tied for the lead", teams[0].TeamName)
+	case margin == 1:
+		lead = fmt.Sprintf("🏆 *%s* leads by 1 stroke", teams[0].TeamName)
+	default:
+		lead = fmt.Sprintf("🏆 *%s* leads by %d strokes", teams[0].TeamName, margin)
+	}
+	updated := "Last updated " + time.Now().In(displayLocation).Format(lastUpdatedLayout)
+
+	payload, err := json.Marshal(map[string]any{
+		// Text is the fallback shown in notifications.
+		"text": strings.ReplaceAll(lead, "*", ""),
+		"blocks": []slackBlock{
+			{Type: "header", Text: &slackText{Type: "plain_text", Text: "⛳ " + tournament + " standings"}},
+			{Type: "section", Text: &slackText{Type: "mrkdwn", Text: lead}},
+			{Type: "section", Text: &slackText{Type: "mrkdwn", Text: strings.Join(standingsLines(teams), "\n")}},
+			{Type: "context", Elements: []slackText{{Type: "mrkdwn", Text: updated}}},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return postWebhook(webhook, payload)
+}
+
+// postWebhook POSTs a JSON payload to url and treats any non-2xx as an error.
+func postWebhook(url string, payload []byte) error {
+	res, err := notifyClient.Post(url, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to post webhook: %v", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("unexpected status code: %d %s", res.StatusCode, res.Status)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNotifyDiscord(t *testing.T) {
+	var content string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+		content = body["content"]
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+	t.Setenv("DISCORD_WEBHOOK_URL", srv.URL)
+
+	teams := []Team{
+		{TeamName: "Team B", PlayerScores: []Player{{FullName: "Total", Total: -9}}},
+		{TeamName: "Team A", PlayerScores: []Player{{FullName: "Total", Total: -4}}},
+	}
+	prev := []SnapshotTeam{{TeamName: "Team A"}, {TeamName: "Team B"}}
+	if err := notifyDiscord(teams, prev); err != nil {
+		t.Fatalf("notifyDiscord: %v", err)
+	}
+
+	for _, want := range []string{"**Team B** takes the lead", "1. Team B (-9)", "2. Team A (-4)"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("message %q missing %q", content, want)
+		}
+	}
+}
+
+func TestNotifySlack(t *testing.T) {
+	var body struct {
+		Text   string       `json:"text"`
+		Blocks []slackBlock `json:"blocks"`
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+	}))
+	defer srv.Close()
+	t.Setenv("SLACK_WEBHOOK_URL", srv.URL)
+
+	teams := []Team{
+		{TeamName: "Team B", PlayerScores: []Player{{FullName: "Total", Total: -9}}},
+		{TeamName: "Team A", PlayerScores: []Player{{FullName: "Total", Total: -4}}},
+	}
+	if err := notifySlack(teams, "Mock Open"); err != nil {
+		t.Fatalf("notifySlack: %v", err)
+	}
+
+	if body.Text != "🏆 Team B leads by 5 strokes" {
+		t.Errorf("text = %q", body.Text)
+	}
+	var types []string
+	for _, b := range body.Blocks {
+		types = append(types, b.Type)
+	}
+	if got := strings.Join(types, ","); got != "header,section,section,context" {
+		t.Fatalf("block types = %s", got)
+	}
+	if got := body.Blocks[0].Text.Text; got != "⛳ Mock Open standings" {
+		t.Errorf("header = %q", got)
+	}
+	if got := body.Blocks[2].Text.Text; got != "1. Team B (-9)\n2. Team A (-4)" {
+		t.Errorf("standings = %q", got)
+	}
+	if got := body.Blocks[3].Elements[0].Text; !strings.HasPrefix(got, "Last updated ") {
+		t.Errorf("context = %q, want the last-updated time", got)
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// ScoreOverride is a commissioner's correction to one player's round, for
+// when the feed has a known error. Score is relative to par, like every
+// other round score.
+type ScoreOverride struct {
+	Player string `json:"player"`
+	Round  int    `json:"round"`
+	Score  int    `json:"score"`
+	Note   string `json:"note,omitempty"`
+}
+
+// overridesPath returns where the score overrides for tournID are kept, so
+// a correction for one event never leaks into another.
+func overridesPath(tournID string) string {
+	return filepath.Join("overrides", tournID+".json")
+}
+
+// loadOverrides reads a JSON array of score overrides from filePath. A
+// missing file means there are none.
+func loadOverrides(filePath string) ([]ScoreOverride, error) {
+	data, err := os.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var overrides []ScoreOverride
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return nil, fmt.Errorf("%s: %v", filePath, err)
+	}
+	for i, o := range overrides {
+		if o.Player == "" || o.Round < 1 || o.Round > 4 {
+			return nil, fmt.Errorf("%s: override %d needs a player and a round from 1-4", filePath, i+1)
+		}
+	}
+	return overrides, nil
+}
+
+// hasOverride reports whether overrides correct any of name's rounds.
+func hasOverride(name string, overrides []ScoreOverride) bool {
+	return slices.ContainsFunc(overrides, func(o ScoreOverride) bool {
+		return normalizeName(o.Player) == normalizeName(name)
+	})
+}
+
+// applyOverrides replaces any of p's rounds that have a commissioner
+// override, logging each one. An overridden round is no longer Unplayed.
+func applyOverrides(p *Player, overrides []ScoreOverride) {
+	for _, o := range overrides {
+		if normalizeName(o.Player) != normalizeName(p.FullName) {
+			continue
+		}
+		was := p.Round(o.Round)
+		p.setRound(o.Round-1, o.Score)
+		p.Unplayed = slices.DeleteFunc(p.Unplayed, func(n int) bool { return n == o.Round })
+		note := ""
+		if o.Note != "" {
+			note = " (" + o.Note + ")"
+		}
+		infof("Override: %s R%d %s → %s%s", p.FullName, o.Round, was, formatToPar(o.Score), note)
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+)
+
+// rateLimitPath holds the fetch token bucket between runs, so separate
+// -refresh invocations share one quota.
+var rateLimitPath = ".ratelimit.json"
+
+// rateLimitMu serializes bucket updates between pools fetching in parallel.
+var rateLimitMu sync.Mutex
+
+// tokenBucket refills at perMinute tokens per minute up to a burst of
+// perMinute, and each fetch spends one token.
+type tokenBucket struct {
+	Tokens  float64   `json:"tokens"`
+	Updated time.Time `json:"updated"`
+}
+
+// take refills b for the time elapsed since its last update and spends a
+// token if one is available.
+func (b *tokenBucket) take(perMinute int, now time.Time) bool {
+	capacity := float64(perMinute)
+	if b.Updated.IsZero() {
+		b.Tokens = capacity
+	} else if elapsed := now.Sub(b.Updated); elapsed > 0 {
+		b.Tokens = min(capacity, b.Tokens+elapsed.Minutes()*capacity)
+	}
+	b.Updated = now
+
+	if b.Tokens < 1 {
+		return false
+	}
+	b.Tokens--
+	return true
+}
+
+// allowFetch reports whether another API request fits within perMinute,
+// spending a token from the bucket stored at path if so. A missing or
+// corrupt bucket file starts a full bucket.
+func allowFetch(path string, perMinute int, now time.Time) (bool, error) {
+	rateLimitMu.Lock()
+	defer rateLimitMu.Unlock()
+
+	var bucket tokenBucket
+	if data, err := os.ReadFile(path); err == nil {
+		if err := json.Unmarshal(data, &bucket); err != nil {
+			warnf("Resetting unreadable rate limit state %s: %v", path, err)
+			bucket = tokenBucket{}
+		}
+	} else if !os.IsNotExist(err) {
+		return false, err
+	}
+
+	ok := bucket.take(perMinute, now)
+	data, err := json.Marshal(bucket)
+	if err != nil {
+		return false, err
+	}
+	return ok, os.WriteFile(path, data, 0o644)
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAllowFetch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ratelimit.json")
+	start := time.Date(2026, 7, 26, 14, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 2; i++ {
+		if ok, err := allowFetch(path, 2, start); err != nil || !ok {
+			t.Fatalf("fetch %d = %v, %v; want allowed", i+1, ok, err)
+		}
+	}
+	if ok, _ := allowFetch(path, 2, start.Add(time.Second)); ok {
+		t.Error("third fetch within the minute was allowed, want limited")
+	}
+	// Half a minute refills one of the two tokens.
+	if ok, _ := allowFetch(path, 2, start.Add(31*time.Second)); !ok {
+		t.Error("fetch after the bucket refilled was limited, want allowed")
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+)
+
+// PickReport is one golfer's record as a pick across the season's history.
+// Contribution sums their totals in the tournaments where they counted
+// toward a team.
+type PickReport struct {
+	Name         string `json:"name"`
+	Picks        int    `json:"picks"`
+	Counted      int    `json:"counted"`
+	Contribution int    `json:"contribution"`
+}
+
+// picksReport aggregates the final snapshot of every tournament history in
+// dir. A golfer on two teams in one tournament counts as two picks.
+// Snapshots taken before players were recorded contribute nothing. The
+// report is sorted best contribution first, then by most picks and name.
+func picksReport(dir string) ([]PickReport, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.jsonl"))
+	if err != nil {
+		return nil, err
+	}
+
+	byName := map[string]*PickReport{}
+	for _, path := range paths {
+		snap, ok, err := lastSnapshot(path)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		for _, t := range snap.Teams {
+			for _, p := range t.Players {
+				key := normalizeName(p.Name)
+				r, ok := byName[key]
+				if !ok {
+					r = &PickReport{Name: p.Name}
+					byName[key] = r
+				}
+				r.Picks++
+				if p.Counted {
+					r.Counted++
+					r.Contribution += p.Total
+				}
+			}
+		}
+	}
+
+	report := make([]PickReport, 0, len(byName))
+	for _, r := range byName {
+		report = append(report, *r)
+	}
+	slices.SortFunc(report, func(a, b PickReport) int {
+		if c := cmp.Compare(a.Contribution, b.Contribution); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(b.Picks, a.Picks); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return report, nil
+}
+
+// writePicksReportText writes report to w as an aligned table.
+func writePicksReportText(report []PickReport, w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "PLAYER\tPICKS\tCOUNTED\tCONTRIBUTION")
+	for _, r := range report {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%s\n", r.Name, r.Picks, r.Counted, formatToPar(r.Contribution))
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPicksReport(t *testing.T) {
+	dir := t.TempDir()
+	team := func(name string, players ...Player) Team {
+		return Team{TeamName: name, PlayerScores: append(players, Player{FullName: "Total"})}
+	}
+
+	// Only the last snapshot of each tournament counts.
+	early := []Team{team("Team A", Player{FullName: "Adam One", Total: 9})}
+	masters := []Team{
+		team("Team A", Player{FullName: "Adam One", Total: -6}, Player{FullName: "Ben Two", Total: 4, Excluded: true}),
+		team("Team B", Player{FullName: "Adam One", Total: -6}),
+	}
+	open := []Team{team("Team B", Player{FullName: "Ben Two", Total: -2})}
+	for _, snap := range []struct {
+		file  string
+		teams []Team
+	}{{"14.jsonl", early}, {"14.jsonl", masters}, {"26.jsonl", open}} {
+		if err := appendSnapshot(filepath.Join(dir, snap.file), snap.teams); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	report, err := picksReport(dir)
+	if err != nil {
+		t.Fatalf("picksReport: %v", err)
+	}
+	want := []PickReport{
+		{Name: "Adam One", Picks: 2, Counted: 2, Contribution: -12},
+		{Name: "Ben Two", Picks: 2, Counted: 1, Contribution: -2},
+	}
+	if len(report) != len(want) {
+		t.Fatalf("report = %+v, want %+v", report, want)
+	}
+	for i := range want {
+		if report[i] != want[i] {
+			t.Errorf("report[%d] = %+v, want %+v", i, report[i], want[i])
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := writePicksReportText(report, &buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "Adam One  2      2        -12") {
+		t.Errorf("text report:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// rosterLockPath returns where the roster manifest for tournID is kept.
+func rosterLockPath(tournID string) string {
+	return filepath.Join("history", tournID+"-rosters.json")
+}
+
+// teamRosterHash fingerprints t's player list and substitutes. Players are
+// normalized and sorted first, so reordering or re-accenting the list
+// doesn't count as a change; substitutes keep their order, which decides
+// who comes in first.
+func teamRosterHash(t Team) string {
+	names := make([]string, len(t.Players))
+	for i, name := range t.Players {
+		names[i] = normalizeName(name)
+	}
+	slices.Sort(names)
+	if len(t.Substitutes) > 0 {
+		names = append(names, "substitutes:")
+		for _, name := range t.Substitutes {
+			names = append(names, normalizeName(name))
+		}
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(names, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// checkRosterLock compares teams against the roster manifest at path,
+// returning the names of teams whose players changed since it was written.
+// Teams added after the lock aren't reported. Without a manifest, one is
+// written when lock is set, locking the current rosters; callers set it
+// once play has started.
+func checkRosterLock(path string, teams []Team, lock bool) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		if !lock {
+			return nil, nil
+		}
+		return nil, writeRosterLock(path, teams)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var locked map[string]string
+	if err := json.Unmarshal(data, &locked); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	var changed []string
+	for _, t := range teams {
+		if hash, ok := locked[t.TeamName]; ok && hash != teamRosterHash(t) {
+			changed = append(changed, t.TeamName)
+		}
+	}
+	return changed, nil
+}
+
+// writeRosterLock saves the roster hash of every team to path.
+func writeRosterLock(path string, teams []Team) error {
+	locked := make(map[string]string, len(teams))
+	for _, t := range teams {
+		locked[t.TeamName] = teamRosterHash(t)
+	}
+	data, err := json.MarshalIndent(locked, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	infof("🔒 Locked rosters for %d teams in %s", len(teams), path)
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// playStarted reports whether any scored player has a round on the card.
+func playStarted(teams []Team) bool {
+	for _, t := range teams {
+		for _, p := range t.PlayerScores {
+			if p.FullName != "Total" && p.RoundsScored > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
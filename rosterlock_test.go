@@ -0,0 +1,71 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestTeamRosterHash(t *testing.T) {
+	a := Team{TeamName: "Team A", Players: []string{"Tom Kim", "Joaquín Niemann"}}
+	b := Team{TeamName: "Team A", Players: []string{"Joaquin Niemann", "Tom Kim"}}
+	if teamRosterHash(a) != teamRosterHash(b) {
+		t.Error("reordered, re-accented roster hashed differently")
+	}
+	c := Team{TeamName: "Team A", Players: []string{"Tom Kim", "Max Homa"}}
+	if teamRosterHash(a) == teamRosterHash(c) {
+		t.Error("different rosters hashed the same")
+	}
+}
+
+func TestCheckRosterLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history", "525-rosters.json")
+	teams := []Team{
+		{TeamName: "Team A", Players: []string{"Tom Kim", "Max Homa"}},
+		{TeamName: "Team B", Players: []string{"Sam Burns", "Sahith Theegala"}},
+	}
+
+	// Nothing is locked until play starts.
+	if changed, err := checkRosterLock(path, teams, false); err != nil || changed != nil {
+		t.Fatalf("checkRosterLock before start = %v, %v", changed, err)
+	}
+	if changed, err := checkRosterLock(path, teams, true); err != nil || changed != nil {
+		t.Fatalf("checkRosterLock at start = %v, %v", changed, err)
+	}
+
+	teams[1].Players = []string{"Sam Burns", "Scottie Scheffler"}
+	changed, err := checkRosterLock(path, teams, true)
+	if err != nil {
+		t.Fatalf("checkRosterLock: %v", err)
+	}
+	if !slices.Equal(changed, []string{"Team B"}) {
+		t.Errorf("changed = %v, want [Team B]", changed)
+	}
+}
+
+func TestBuildTeamsRosterLockReadOnly(t *testing.T) {
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "A.json"), []byte(`{"teamName": "Team A", "players": ["Adam One", "Ben Two"]}`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "history", "525-rosters.json")
+	cfg := Config{TeamsDir: dir, Leaderboard: "testdata/leaderboard.json", Members: []string{"A"}, RosterLock: path}
+
+	cfg.RosterLockReadOnly = true
+	if _, _, err := buildTeams(cfg, ScoreOptions{Count: 4, CutPenalty: 3}); err != nil {
+		t.Fatalf("buildTeams: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("read-only run wrote %s", path)
+	}
+
+	cfg.RosterLockReadOnly = false
+	if _, _, err := buildTeams(cfg, ScoreOptions{Count: 4, CutPenalty: 3}); err != nil {
+		t.Fatalf("buildTeams: %v", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("publishing run didn't lock rosters: %v", err)
+	}
+}
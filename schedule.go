@@ -0,0 +1,119 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+)
+
+// TournamentInfo is one event from the API's schedule endpoint.
+type TournamentInfo struct {
+	TournID string `json:"tournId"`
+	Name    string `json:"name"`
+	Date    struct {
+		Start string `json:"start"`
+		End   string `json:"end"`
+	} `json:"date"`
+}
+
+type Schedule struct {
+	Year     string           `json:"year"`
+	Schedule []TournamentInfo `json:"schedule"`
+}
+
+// StartDate and EndDate parse the schedule's dates, which come back both
+// with and without a zone suffix, so only the calendar day is used.
+func (t TournamentInfo) StartDate() (time.Time, error) { return parseScheduleDate(t.Date.Start) }
+func (t TournamentInfo) EndDate() (time.Time, error)   { return parseScheduleDate(t.Date.End) }
+
+func parseScheduleDate(s string) (time.Time, error) {
+	if len(s) < len("2006-01-02") {
+		return time.Time{}, fmt.Errorf("invalid schedule date %q", s)
+	}
+	return time.Parse("2006-01-02", s[:len("2006-01-02")])
+}
+
+func loadSchedule(filePath string) (Schedule, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return Schedule{}, err
+	}
+	defer file.Close()
+
+	var schedule Schedule
+	if err := json.NewDecoder(file).Decode(&schedule); err != nil {
+		return Schedule{}, fmt.Errorf("%s: %v", filePath, err)
+	}
+	return schedule, nil
+}
+
+// findTournament returns the schedule entry for tournID, if any.
+func (s Schedule) findTournament(tournID string) (TournamentInfo, bool) {
+	for _, t := range s.Schedule {
+		if t.TournID == tournID {
+			return t, true
+		}
+	}
+	return TournamentInfo{}, false
+}
+
+// schedulePath returns where the schedule for year is cached.
+func schedulePath(year string) string {
+	return filepath.Join("api", "schedule-"+year+".json")
+}
+
+// cachedSchedule returns the schedule for year without fetching it: the
+// copy the schedule command cached, or else the checked-in schedule.json if
+// it's for that year.
+func cachedSchedule(year string) (Schedule, bool) {
+	for _, path := range []string{schedulePath(year), "schedule.json"} {
+		if schedule, err := loadSchedule(path); err == nil && schedule.Year == year {
+			return schedule, true
+		}
+	}
+	return Schedule{}, false
+}
+
+// fetchSchedule returns the schedule for opts.Year, cached at schedulePath
+// the way the leaderboard is cached: a copy younger than opts.MaxAge is
+// reused, and otherwise fetchJSON revalidates it with its ETag. If the
+// API can't be reached, a cached copy is used with a warning.
+func fetchSchedule(opts FetchOptions) ([]TournamentInfo, error) {
+	if err := checkYear(opts.Year); err != nil {
+		return nil, err
+	}
+	opts.OutPath = schedulePath(opts.Year)
+	opts.SourceURL, opts.Headers = "", nil
+	url := fmt.Sprintf("%s/schedule?orgId=%s&year=%s", apiBaseURL, opts.OrgID, opts.Year)
+	validate := func(body []byte) error {
+		var schedule Schedule
+		return json.Unmarshal(body, &schedule)
+	}
+	if _, err := fetchJSON(opts, url, validate); err != nil {
+		if _, statErr := os.Stat(opts.OutPath); statErr != nil {
+			return nil, fmt.Errorf("failed to fetch schedule: %v", err)
+		}
+		warnf("Failed to fetch schedule (%v); using cached %s", err, opts.OutPath)
+	}
+
+	schedule, err := loadSchedule(opts.OutPath)
+	if err != nil {
+		return nil, err
+	}
+	return schedule.Schedule, nil
+}
+
+// writeScheduleText writes events to w as an aligned table of IDs, names,
+// and dates.
+func writeScheduleText(events []TournamentInfo, w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "TOURN\tNAME\tDATES")
+	for _, e := range events {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", e.TournID, e.Name, formatDateRange(e))
+	}
+	return tw.Flush()
+}
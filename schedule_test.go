@@ -0,0 +1,114 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFetchSchedule(t *testing.T) {
+	requests, notModified := 0, 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/schedule" || r.URL.Query().Get("year") != "2025" {
+			t.Errorf("request for %s, want /schedule?year=2025", r.URL)
+		}
+		if r.Header.Get("If-None-Match") == `"s1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"s1"`)
+		w.Write([]byte(`{"year": "2025", "schedule": [{"tournId": "014", "name": "Masters Tournament", "date": {"start": "2025-04-10T00:00:00", "end": "2025-04-13T00:00:00"}}]}`))
+	}))
+	defer srv.Close()
+
+	prevURL := apiBaseURL
+	apiBaseURL = srv.URL
+	defer func() { apiBaseURL = prevURL }()
+	t.Setenv("RAPID_GOLF_API_KEY", "test-key")
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	// A fresh cache within -max-age is reused without a request.
+	opts := FetchOptions{OrgID: "1", Year: "2025", Timeout: time.Second, Retries: 1, MaxAge: time.Hour}
+	for range 2 {
+		events, err := fetchSchedule(opts)
+		if err != nil {
+			t.Fatalf("fetchSchedule: %v", err)
+		}
+		if len(events) != 1 || events[0].TournID != "014" {
+			t.Fatalf("events = %+v, want the Masters", events)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("%d requests, want 1 with the schedule cached", requests)
+	}
+
+	// Without -max-age the cache is revalidated with its ETag.
+	opts.MaxAge = 0
+	if events, err := fetchSchedule(opts); err != nil || len(events) != 1 {
+		t.Fatalf("fetchSchedule after 304 = %v, %v; want the cached Masters", events, err)
+	}
+	if requests != 2 || notModified != 1 {
+		t.Errorf("%d requests, %d not modified; want 2, 1", requests, notModified)
+	}
+
+	// -force downloads it in full.
+	opts.Force = true
+	events, _ := fetchSchedule(opts)
+	if requests != 3 || notModified != 1 {
+		t.Errorf("%d requests, %d not modified after -force; want 3, 1", requests, notModified)
+	}
+
+	var buf strings.Builder
+	if err := writeScheduleText(events, &buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "014    Masters Tournament  Apr 10-13, 2025") {
+		t.Errorf("schedule text:\n%s", buf.String())
+	}
+}
+
+func TestLoadLeaderboardMetaCachedSchedule(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	if err := os.Mkdir("api", 0o755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		schedulePath("2025"): `{"year": "2025", "schedule": [{"tournId": "014", "name": "Masters Tournament", "date": {"start": "2025-04-10T00:00:00", "end": "2025-04-13T00:00:00"}}]}`,
+		"schedule.json":      `{"year": "2026", "schedule": [{"tournId": "014", "name": "Masters Tournament", "date": {"start": "2026-04-09T00:00:00", "end": "2026-04-12T00:00:00"}}]}`,
+		"leaderboard.json":   `{"tournId": "014", "year": "2025", "leaderboardRows": []}`,
+	}
+	for path, data := range files {
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	meta, err := loadLeaderboardMeta("leaderboard.json")
+	if err != nil {
+		t.Fatalf("loadLeaderboardMeta: %v", err)
+	}
+	if meta.Name != "Masters Tournament" || meta.Dates != "Apr 10-13, 2025" {
+		t.Errorf("Name, Dates = %q, %q; want the cached 2025 schedule's", meta.Name, meta.Dates)
+	}
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"html/template"
+	"io"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// defaultSeriesPoints is the -points table: 10 for a win down to 1 for
+// fifth, and nothing below that.
+const defaultSeriesPoints = "10,7,5,3,1"
+
+// SeriesResult is one team's finish in one tournament of the series.
+type SeriesResult struct {
+	TournID string `json:"tournId"`
+	Rank    int    `json:"rank"`
+	Total   int    `json:"total"`
+	Points  int    `json:"points"`
+}
+
+// SeriesStanding is a team's season in the points series. Results are in
+// tournament order and skip events the team wasn't in.
+type SeriesStanding struct {
+	Rank     int            `json:"rank"`
+	TeamName string         `json:"teamName"`
+	Points   int            `json:"points"`
+	Wins     int            `json:"wins"`
+	Results  []SeriesResult `json:"results"`
+}
+
+// Result returns the team's result in tournID, or nil if they didn't play it.
+func (s SeriesStanding) Result(tournID string) *SeriesResult {
+	for i := range s.Results {
+		if s.Results[i].TournID == tournID {
+			return &s.Results[i]
+		}
+	}
+	return nil
+}
+
+// seriesPoints returns what finishing at rank (one-based) is worth under
+// table, where table[0] is the winner's points. Ranks past the end of the
+// table earn nothing.
+func seriesPoints(rank int, table []int) int {
+	if rank < 1 || rank > len(table) {
+		return 0
+	}
+	return table[rank-1]
+}
+
+// parsePointsTable parses a -points value like "10,7,5" into a points table.
+func parsePointsTable(s string) ([]int, error) {
+	var table []int
+	for _, field := range strings.Split(s, ",") {
+		points, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || points < 0 {
+			return nil, fmt.Errorf("invalid points %q: want a comma-separated list of non-negative numbers", field)
+		}
+		table = append(table, points)
+	}
+	return table, nil
+}
+
+// seriesStandings awards table's points for the final snapshot of every
+// tournament history in dir, returning the season standings and the
+// tournament IDs they cover in file order. Teams are sorted by the most
+// points, then the most wins and name.
+func seriesStandings(dir string, table []int) ([]SeriesStanding, []string, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.jsonl"))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var tourns []string
+	byTeam := map[string]*SeriesStanding{}
+	for _, path := range paths {
+		snap, ok, err := lastSnapshot(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !ok {
+			continue
+		}
+		tournID := strings.TrimSuffix(filepath.Base(path), ".jsonl")
+		tourns = append(tourns, tournID)
+		for _, t := range snap.Teams {
+			s, ok := byTeam[t.TeamName]
+			if !ok {
+				s = &SeriesStanding{TeamName: t.TeamName}
+				byTeam[t.TeamName] = s
+			}
+			points := seriesPoints(t.Rank, table)
+			s.Points += points
+			if t.Rank == 1 {
+				s.Wins++
+			}
+			s.Results = append(s.Results, SeriesResult{TournID: tournID, Rank: t.Rank, Total: t.Total, Points: points})
+		}
+	}
+
+	standings := make([]SeriesStanding, 0, len(byTeam))
+	for _, s := range byTeam {
+		standings = append(standings, *s)
+	}
+	slices.SortFunc(standings, func(a, b SeriesStanding) int {
+		if c := cmp.Compare(b.Points, a.Points); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(b.Wins, a.Wins); c != 0 {
+			return c
+		}
+		return strings.Compare(a.TeamName, b.TeamName)
+	})
+	for i := range standings {
+		standings[i].Rank = i + 1
+	}
+	return standings, tourns, nil
+}
+
+// writeSeriesText writes standings to w as an aligned table with a column
+// of points per tournament.
+func writeSeriesText(standings []SeriesStanding, tourns []string, w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprint(tw, "#\tTEAM\tPOINTS\tWINS")
+	for _, id := range tourns {
+		fmt.Fprintf(tw, "\t%s", id)
+	}
+	fmt.Fprintln(tw)
+	for _, s := range standings {
+		fmt.Fprintf(tw, "%d\t%s\t%d\t%d", s.Rank, s.TeamName, s.Points, s.Wins)
+		for _, id := range tourns {
+			if r := s.Result(id); r != nil {
+				fmt.Fprintf(tw, "\t%d", r.Points)
+			} else {
+				fmt.Fprint(tw, "\t-")
+			}
+		}
+		fmt.Fprintln(tw)
+	}
+	return tw.Flush()
+}
+
+// SeriesPage is the data the series template renders.
+type SeriesPage struct {
+	Standings   []SeriesStanding
+	Tourns      []string
+	Points      []int
+	LastUpdated string
+}
+
+func newSeriesPage(standings []SeriesStanding, tourns []string, table []int) SeriesPage {
+	page := SeriesPage{Standings: standings, Tourns: tourns, Points: table}
+	if !noTimestamp {
+		page.LastUpdated = time.Now().In(displayLocation).Format(lastUpdatedLayout)
+	}
+	return page
+}
+
+// writeSeriesPage executes the embedded season standings template into w.
+func writeSeriesPage(w io.Writer, page SeriesPage) error {
+	tmpl, err := template.New("series.html").Funcs(template.FuncMap{
+		"toPar": formatToPar,
+	}).ParseFS(embeddedTemplates, "templates/series.html")
+	if err != nil {
+		return err
+	}
+	return tmpl.ExecuteTemplate(w, "series", page)
+}
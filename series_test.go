@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSeriesPoints(t *testing.T) {
+	table := []int{10, 7, 5}
+	tests := []struct {
+		rank, want int
+	}{
+		{1, 10},
+		{3, 5},
+		{4, 0},
+		{0, 0},
+	}
+	for _, tt := range tests {
+		if got := seriesPoints(tt.rank, table); got != tt.want {
+			t.Errorf("seriesPoints(%d) = %d, want %d", tt.rank, got, tt.want)
+		}
+	}
+}
+
+func TestSeriesStandings(t *testing.T) {
+	dir := t.TempDir()
+	team := func(name string, total int) Team {
+		return Team{TeamName: name, PlayerScores: []Player{{FullName: "Total", Total: total}}}
+	}
+
+	// Only the last snapshot of each tournament counts, and Team C skipped
+	// the second event.
+	for _, snap := range []struct {
+		file  string
+		teams []Team
+	}{
+		{"014.jsonl", []Team{team("Team C", -9), team("Team A", -4), team("Team B", -1)}},
+		{"014.jsonl", []Team{team("Team A", -8), team("Team C", -6), team("Team B", -1)}},
+		{"026.jsonl", []Team{team("Team B", -5), team("Team A", -3)}},
+	} {
+		if err := appendSnapshot(filepath.Join(dir, snap.file), snap.teams); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	standings, tourns, err := seriesStandings(dir, []int{10, 7, 5})
+	if err != nil {
+		t.Fatalf("seriesStandings: %v", err)
+	}
+	if strings.Join(tourns, ",") != "014,026" {
+		t.Errorf("tourns = %v, want [014 026]", tourns)
+	}
+	want := []struct {
+		team         string
+		points, wins int
+	}{
+		{"Team A", 17, 1},
+		{"Team B", 15, 1},
+		{"Team C", 7, 0},
+	}
+	if len(standings) != len(want) {
+		t.Fatalf("standings = %+v", standings)
+	}
+	for i, w := range want {
+		s := standings[i]
+		if s.Rank != i+1 || s.TeamName != w.team || s.Points != w.points || s.Wins != w.wins {
+			t.Errorf("standings[%d] = %d %s %d points %d wins, want %d %s %d points %d wins", i, s.Rank, s.TeamName, s.Points, s.Wins, i+1, w.team, w.points, w.wins)
+		}
+	}
+	if standings[2].Result("026") != nil {
+		t.Error("Team C has a result for a tournament it wasn't in")
+	}
+
+	var text bytes.Buffer
+	if err := writeSeriesText(standings, tourns, &text); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(text.String(), "Team C  7       0     7    -") {
+		t.Errorf("text report missing Team C's row:\n%s", text.String())
+	}
+
+	var page bytes.Buffer
+	if err := writeSeriesPage(&page, newSeriesPage(standings, tourns, []int{10, 7, 5})); err != nil {
+		t.Fatalf("writeSeriesPage: %v", err)
+	}
+	for _, want := range []string{"Season Standings", "Points by finish: 10, 7, 5", `<td class="team">Team A</td>`, "(2, -3)"} {
+		if !strings.Contains(page.String(), want) {
+			t.Errorf("page missing %q", want)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"slices"
+	"time"
+)
+
+// serveScoreboard serves a live scoreboard on addr, rebuilding the teams from
+// the leaderboard file on every page load and marking rank movement against
+// the history at movementPath. /api/standings serves the same standings as
+// JSON; its ?tourn= query selects one of tcs instead of cfg.
+func serveScoreboard(addr string, cfg Config, score ScoreOptions, movementPath string, tcs []TournamentConfig) error {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ok\n"))
+	})
+
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		writeMetrics(w)
+	})
+
+	mux.Handle("/static/", http.FileServer(http.Dir("docs")))
+
+	mux.HandleFunc("/api/standings", func(w http.ResponseWriter, r *http.Request) {
+		pool, poolScore := cfg, score
+		if tourn := r.URL.Query().Get("tourn"); tourn != "" {
+			i := slices.IndexFunc(tcs, func(tc TournamentConfig) bool { return tc.TournID == tourn })
+			if i < 0 {
+				http.Error(w, "unknown tournament "+tourn, http.StatusNotFound)
+				return
+			}
+			pool, poolScore = tcs[i].pool(), tcs[i].Score
+		}
+
+		teams, _, err := buildTeams(pool, poolScore)
+		if err != nil {
+			errorf("Failed to build teams: %v", err)
+			http.Error(w, "failed to build standings", http.StatusInternalServerError)
+			return
+		}
+
+		var buf bytes.Buffer
+		if err := encodeStandings(teams, &buf); err != nil {
+			errorf("Failed to encode standings: %v", err)
+			http.Error(w, "failed to encode standings", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		buf.WriteTo(w)
+	})
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+
+		teams, skipped, err := buildTeams(cfg, score)
+		if err != nil {
+			errorf("Failed to build teams: %v", err)
+			http.Error(w, "failed to build scoreboard", http.StatusInternalServerError)
+			return
+		}
+		loadRankDeltas(movementPath, teams)
+
+		// Render into a buffer first so a template error doesn't leave the
+		// client with half a page.
+		var buf bytes.Buffer
+		if err := writeScoreboard(&buf, newPageData(teams, skipped, tournamentMeta(cfg.Leaderboard, tournName), score)); err != nil {
+			errorf("Failed to render scoreboard: %v", err)
+			http.Error(w, "failed to render scoreboard", http.StatusInternalServerError)
+			return
+		}
+		if checkHTML {
+			if err := checkPage(buf.Bytes()); err != nil {
+				errorf("Failed to render scoreboard: %v", err)
+				http.Error(w, "failed to render scoreboard", http.StatusInternalServerError)
+				return
+			}
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		buf.WriteTo(w)
+	})
+
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	infof("Serving scoreboard on %s", addr)
+	return srv.ListenAndServe()
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// teeTimeDuration is how long each calendar event blocks out: roughly a
+// round of tournament golf.
+const teeTimeDuration = 4*time.Hour + 30*time.Minute
+
+// icsTimeLayout is an iCalendar UTC date-time.
+const icsTimeLayout = "20060102T150405Z"
+
+// feedTimeLayout is the feed's ISO form of a timestamp. It carries no zone;
+// the times are UTC, so a "12:00pm" tee time at a Central time event comes
+// through as "...T17:00:00".
+const feedTimeLayout = "2006-01-02T15:04:05"
+
+// epochMillis is a leaderboard timestamp in milliseconds since the epoch.
+// The feed sends it as an ISO time in UTC (see feedTimeLayout), a number, a
+// numeric string, or MongoDB's extended JSON ({"$date": {"$numberLong":
+// "..."}}). Anything else decodes as zero rather than failing the whole
+// leaderboard.
+type epochMillis int64
+
+func (m *epochMillis) UnmarshalJSON(data []byte) error {
+	var ext struct {
+		Date struct {
+			NumberLong string `json:"$numberLong"`
+		} `json:"$date"`
+	}
+	s := string(bytes.Trim(data, `"`))
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) && json.Unmarshal(data, &ext) == nil {
+		s = ext.Date.NumberLong
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		n = 0
+		if t, err := time.ParseInLocation(feedTimeLayout, s, time.UTC); err == nil {
+			n = t.UnixMilli()
+		} else if t, err := time.Parse(time.RFC3339, s); err == nil {
+			n = t.UnixMilli()
+		}
+	}
+	*m = epochMillis(n)
+	return nil
+}
+
+// Time returns m as a time, or the zero time when it's unset.
+func (m epochMillis) Time() time.Time {
+	if m <= 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(int64(m))
+}
+
+// writeTeeTimesICS writes an iCalendar file to w with an event for each of
+// players' tee times, earliest first. Players without a tee time are left
+// out, and a player listed twice, such as one picked by two teams, gets
+// one event.
+func writeTeeTimesICS(players []Player, w io.Writer) error {
+	var teeing []Player
+	for _, p := range players {
+		if p.TeeTime == nil || p.FullName == "Total" {
+			continue
+		}
+		if slices.ContainsFunc(teeing, func(q Player) bool { return normalizeName(q.FullName) == normalizeName(p.FullName) }) {
+			continue
+		}
+		teeing = append(teeing, p)
+	}
+	slices.SortFunc(teeing, func(a, b Player) int {
+		if c := a.TeeTime.Compare(*b.TeeTime); c != 0 {
+			return c
+		}
+		return strings.Compare(a.FullName, b.FullName)
+	})
+
+	var b strings.Builder
+	b.WriteString("BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//pga-tracker//Tee Times//EN\r\nCALSCALE:GREGORIAN\r\n")
+	for _, p := range teeing {
+		summary := p.FullName + " tees off"
+		if p.TeeRound > 0 {
+			summary = fmt.Sprintf("%s tees off in R%d", p.FullName, p.TeeRound)
+		}
+		// DTSTAMP is the tee time too, so unchanged tee times always
+		// produce the same file.
+		start := p.TeeTime.UTC().Format(icsTimeLayout)
+		b.WriteString("BEGIN:VEVENT\r\n")
+		fmt.Fprintf(&b, "UID:%s-%s@pga-tracker\r\n", p.TeeTime.UTC().Format("20060102"), icsUID(p.FullName))
+		fmt.Fprintf(&b, "DTSTAMP:%s\r\nDTSTART:%s\r\n", start, start)
+		fmt.Fprintf(&b, "DTEND:%s\r\n", p.TeeTime.Add(teeTimeDuration).UTC().Format(icsTimeLayout))
+		fmt.Fprintf(&b, "SUMMARY:%s\r\n", icsText(summary))
+		b.WriteString("END:VEVENT\r\n")
+	}
+	b.WriteString("END:VCALENDAR\r\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// icsText escapes s for an iCalendar text value.
+func icsText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(s)
+}
+
+// icsUID turns a player's name into the dash-separated form of
+// normalizeName used in event UIDs.
+func icsUID(name string) string {
+	return strings.ReplaceAll(normalizeName(name), " ", "-")
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEpochMillisUnmarshal(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int64
+	}{
+		{`1753284900000`, 1753284900000},
+		{`"1753284900000"`, 1753284900000},
+		{`{"$date": {"$numberLong": "1753284900000"}}`, 1753284900000},
+		{`"2026-07-23T17:00:00"`, 1784826000000},
+		{`"2026-07-23T12:00:00-05:00"`, 1784826000000},
+		{`null`, 0},
+		{`"soon"`, 0},
+	}
+	for _, tt := range tests {
+		var m epochMillis
+		if err := json.Unmarshal([]byte(tt.in), &m); err != nil || int64(m) != tt.want {
+			t.Errorf("Unmarshal(%s) = %d, %v; want %d", tt.in, m, err, tt.want)
+		}
+	}
+}
+
+func TestWriteTeeTimesICS(t *testing.T) {
+	early := time.Date(2026, 7, 23, 12, 35, 0, 0, time.UTC)
+	late := early.Add(5 * time.Hour)
+	players := []Player{
+		{FullName: "Ben Two, Jr.", TeeTime: &late, TeeRound: 1},
+		{FullName: "Adam One", TeeTime: &early, TeeRound: 1},
+		{FullName: "Carl Three"},
+		{FullName: "Adam One", TeeTime: &early, TeeRound: 1},
+		{FullName: "Total"},
+	}
+
+	var b strings.Builder
+	if err := writeTeeTimesICS(players, &b); err != nil {
+		t.Fatalf("writeTeeTimesICS: %v", err)
+	}
+	ics := b.String()
+	if n := strings.Count(ics, "BEGIN:VEVENT"); n != 2 {
+		t.Errorf("%d events, want 2 (no tee time for Carl Three, Adam One once)", n)
+	}
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"UID:20260723-adam-one@pga-tracker\r\nDTSTAMP:20260723T123500Z\r\nDTSTART:20260723T123500Z\r\nDTEND:20260723T170500Z\r\nSUMMARY:Adam One tees off in R1\r\n",
+		`SUMMARY:Ben Two\, Jr. tees off in R1`,
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(ics, want) {
+			t.Errorf("calendar missing %q:\n%s", want, ics)
+		}
+	}
+	if strings.Index(ics, "Adam One") > strings.Index(ics, "Ben Two") {
+		t.Error("events aren't in tee time order")
+	}
+}
+
+func TestGetTeamScoresTeeTime(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "leaderboard.json")
+	err := os.WriteFile(path, []byte(`{"roundId": 2, "leaderboardRows": [
+		{"firstName": "Adam", "lastName": "One", "position": "1", "teeTime": "8:35am", "teeTimeTimestamp": {"$date": {"$numberLong": "1784810100000"}}},
+		{"firstName": "Ben", "lastName": "Two", "position": "2", "currentRound": 3, "teeTimeTimestamp": 1784810100000},
+		{"firstName": "Carl", "lastName": "Three", "position": "3"}
+	]}`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	team := getTeamScores(mustLoadLeaderboard(t, path), Team{Players: []string{"Adam One", "Ben Two", "Carl Three"}}, ScoreOptions{Count: 3})
+
+	want := time.UnixMilli(1784810100000)
+	rounds := map[string]int{"Adam One": 2, "Ben Two": 3}
+	for _, p := range team[:3] {
+		round, ok := rounds[p.FullName]
+		switch {
+		case !ok && p.TeeTime != nil:
+			t.Errorf("%s TeeTime = %v, want none", p.FullName, p.TeeTime)
+		case ok && (p.TeeTime == nil || !p.TeeTime.Equal(want) || p.TeeRound != round):
+			t.Errorf("%s TeeTime, TeeRound = %v, %d; want %v, %d", p.FullName, p.TeeTime, p.TeeRound, want, round)
+		}
+	}
+}
+
+func TestTeeTimesFromFeedRow(t *testing.T) {
+	// A row as leaderboard.json has it, with the feed's zone-less UTC
+	// timestamp for a 12:00pm Central tee time.
+	path := filepath.Join(t.TempDir(), "leaderboard.json")
+	err := os.WriteFile(path, []byte(`{"roundId": 1, "leaderboardRows": [{
+		"courseId": "883",
+		"currentHole": 18,
+		"currentRound": 1,
+		"currentRoundScore": "-9",
+		"firstName": "Ben",
+		"isAmateur": false,
+		"lastName": "Kohles",
+		"playerId": "36884",
+		"position": "1",
+		"roundComplete": true,
+		"rounds": [{"courseId": "883", "courseName": "TPC Twin Cities", "roundId": 1, "scoreToPar": "-9", "strokes": 62}],
+		"startingHole": 1,
+		"status": "complete",
+		"teeTime": "12:00pm",
+		"teeTimeTimestamp": "2026-07-23T17:00:00",
+		"thru": "F",
+		"total": "-9",
+		"totalStrokesFromCompletedRounds": "62"
+	}]}`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	team := getTeamScores(mustLoadLeaderboard(t, path), Team{Players: []string{"Ben Kohles"}}, ScoreOptions{Count: 1})
+
+	var b strings.Builder
+	if err := writeTeeTimesICS(team, &b); err != nil {
+		t.Fatalf("writeTeeTimesICS: %v", err)
+	}
+	if want := "DTSTART:20260723T170000Z\r\n"; !strings.Contains(b.String(), want) {
+		t.Errorf("calendar missing %q:\n%s", want, b.String())
+	}
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// TournamentConfig describes one pool in a -tournaments file. Pool, Fetch,
+// Score, and Refresh aren't read from the file; run fills them in from the
+// pool config and command-line flags.
+type TournamentConfig struct {
+	TournID         string `json:"tournId"`
+	Year            string `json:"year"`
+	Name            string `json:"name"`
+	TeamsDir        string `json:"teamsDir"`
+	OutputPath      string `json:"outputPath"`
+	LeaderboardPath string `json:"leaderboardPath"`
+
+	Pool    Config       `json:"-"`
+	Fetch   FetchOptions `json:"-"`
+	Score   ScoreOptions `json:"-"`
+	Refresh bool         `json:"-"`
+}
+
+// loadTournaments reads a JSON array of tournaments from filePath. Each
+// entry needs a tournId and outputPath; leaderboardPath defaults to
+// leaderboard-<tournId>.json so pools never overwrite each other's data.
+func loadTournaments(filePath string) ([]TournamentConfig, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var tcs []TournamentConfig
+	if err := json.NewDecoder(file).Decode(&tcs); err != nil {
+		return nil, fmt.Errorf("%s: %v", filePath, err)
+	}
+	for i, tc := range tcs {
+		if tc.TournID == "" || tc.OutputPath == "" {
+			return nil, fmt.Errorf("%s: tournament %d needs a tournId and outputPath", filePath, i+1)
+		}
+		if tc.LeaderboardPath == "" {
+			tcs[i].LeaderboardPath = fmt.Sprintf("leaderboard-%s.json", tc.TournID)
+		}
+	}
+	return tcs, nil
+}
+
+// pool returns the pool config with this tournament's leaderboard and teams
+// directory filled in.
+func (tc TournamentConfig) pool() Config {
+	pool := tc.Pool
+	pool.Leaderboard = tc.LeaderboardPath
+	pool.RosterLock = rosterLockPath(tc.TournID)
+	if tc.TeamsDir != "" {
+		pool.TeamsDir = tc.TeamsDir
+	}
+	return pool
+}
+
+// processTournament fetches, scores, and renders a single pool.
+func processTournament(tc TournamentConfig) error {
+	fetch := tc.Fetch
+	fetch.TournID = tc.TournID
+	fetch.OutPath = tc.LeaderboardPath
+	if tc.Year != "" {
+		fetch.Year = tc.Year
+	}
+
+	pool := tc.pool()
+	if _, err := os.Stat(tc.LeaderboardPath); tc.Refresh || os.IsNotExist(err) {
+		if err := fetchLeaderboard(fetch); err != nil {
+			return fmt.Errorf("failed to refresh leaderboard: %v", err)
+		}
+	}
+
+	teams, skipped, err := buildTeams(pool, tc.Score)
+	if err != nil {
+		return err
+	}
+
+	meta := tournamentMeta(tc.LeaderboardPath, "Tournament "+tc.TournID)
+	if tc.Name != "" {
+		meta.Name = tc.Name
+	}
+	if err := renderScoreboard(newPageData(teams, skipped, meta, tc.Score), tc.OutputPath); err != nil {
+		return fmt.Errorf("render failed: %v", err)
+	}
+	infof("✅ Rendered %s to %s", meta.Name, tc.OutputPath)
+	return nil
+}
+
+// processTournaments runs processTournament for every pool in parallel and
+// returns a combined error naming each pool that failed.
+func processTournaments(tcs []TournamentConfig) error {
+	var wg sync.WaitGroup
+	errs := make([]error, len(tcs))
+	for i, tc := range tcs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = processTournament(tc)
+		}()
+	}
+	wg.Wait()
+
+	var failures []string
+	for i, err := range errs {
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", tcs[i].TournID, err))
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("%d of %d tournaments failed: %s", len(failures), len(tcs), strings.Join(failures, "; "))
+	}
+	return nil
+}
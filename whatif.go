@@ -0,0 +1,31 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// loadWhatIf reads a what-if file mapping player names to hypothetical R4
+// scores relative to par, e.g. {"Tom Kim": -4}.
+func loadWhatIf(filePath string) (map[string]int, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var scores map[string]int
+	if err := json.Unmarshal(data, &scores); err != nil {
+		return nil, fmt.Errorf("%s: %v", filePath, err)
+	}
+	if scores == nil {
+		scores = map[string]int{}
+	}
+	return scores, nil
+}
+
+// whatIfScore returns the simulated R4 score for name, matching names the
+// same way team files are matched against the leaderboard.
+func (o ScoreOptions) whatIfScore(name string) (int, bool) {
+	return lookupName(o.WhatIf, name)
+}